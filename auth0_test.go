@@ -64,6 +64,7 @@ func TestString(t *testing.T) {
 		{String(""), ""},
 		{String("foo"), "foo"},
 		{String("bar"), "bar"},
+		{Stringf("foo-%d", 1), "foo-1"},
 	} {
 		have := StringValue(test.in)
 		if have != test.expected {
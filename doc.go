@@ -48,7 +48,15 @@ The auth0 package also handles rate limiting by respecting the `X-Ratelimit-*`
 headers sent by the server.
 
 The amount of time the client waits for the rate limit to be reset is taken from
-the `X-Ratelimit-Reset` header as the amount of seconds to wait.
+the `Retry-After` or `X-Ratelimit-Reset` headers. If neither is present, the
+client backs off exponentially between attempts.
+
+Rate limited requests are retried up to 10 times, after which the rate limit
+error is returned. This can be configured using the WithRetries option.
+
+    m, err := management.New(domain,
+        management.WithClientCredentials(id, secret),
+        management.WithRetries(3))
 
 Configuration
 
@@ -73,5 +81,58 @@ on a request basis.
         management.Parameter("strategy", "auth0"),
     )
 
+Contexts
+
+The context configured using the WithContext option is used by every request,
+and can be overridden on a per-request basis using the Context request option.
+Cancelling the context aborts any in-flight request, in which case the
+returned error wraps context.Canceled or context.DeadlineExceeded.
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    u, err := m.User.Read(id, management.Context(ctx))
+    if errors.Is(err, context.DeadlineExceeded) {
+        // handle timeout
+    }
+
+Pagination
+
+Most managers that return lists support offset pagination using the Page and
+PerPage request options.
+
+    l, err := m.User.List(management.Page(1), management.PerPage(100))
+
+The User, Client, Connection and Role managers also provide a ListAll method
+returning an iterator which requests additional pages as needed.
+
+    it := m.User.ListAll(management.PerPage(100))
+    for {
+        u, err := it.Next()
+        if err == management.ErrIteratorDone {
+            break
+        }
+        if err != nil {
+            // handle err
+        }
+        // use u
+    }
+
+The Log manager only supports offset pagination for the first 1000 log
+entries. Checkpoint pagination using the From and Take request options, or the
+ListCheckpoint helper, should be used to retrieve log entries beyond those.
+
+    var from string
+    for {
+        logs, next, err := m.Log.ListCheckpoint(from, 100)
+        if err != nil {
+            // handle err
+        }
+        if len(logs) == 0 {
+            break
+        }
+        from = next
+    }
+
 */
 package auth0
@@ -1,13 +1,18 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
+	"math/rand"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/rehttp"
@@ -30,32 +35,117 @@ func (rf RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return rf(req)
 }
 
-// RateLimitTransport wraps base transport with rate limiting functionality.
+// DefaultMaxRetries is the default amount of times a rate limited request is
+// retried.
+const DefaultMaxRetries = 10
+
+const (
+	// retryBaseDelay is the maximum delay before the first retry when the
+	// server does not specify when the rate limit is reset. It is doubled for
+	// each subsequent retry, up to retryMaxDelay.
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+// RateLimitTransport wraps base transport with rate limiting functionality,
+// retrying rate limited requests up to DefaultMaxRetries times.
 //
-// When a 429 status code is returned by the remote server, the
-// "X-RateLimit-Reset" header is used to determine how long the transport will
-// wait until re-issuing the failed request.
+// See RetryTransport for more information.
 func RateLimitTransport(base http.RoundTripper) http.RoundTripper {
+	return RetryTransport(base, DefaultMaxRetries)
+}
+
+// RetryTransport wraps base transport with rate limiting functionality.
+//
+// When a 429 or 503 status code is returned by the remote server, the
+// "Retry-After" or "X-RateLimit-Reset" headers are used to determine how long
+// the transport will wait until re-issuing the failed request. If neither is
+// present, a jittered exponential backoff is used instead.
+//
+// The request, including its body, is retried at most maxRetries times, after
+// which the last response is returned. The request is not retried if its
+// context is done or its deadline could be exceeded before the next attempt.
+func RetryTransport(base http.RoundTripper, maxRetries int) http.RoundTripper {
 	if base == nil {
 		base = http.DefaultTransport
 	}
-	return rehttp.NewTransport(base, retry, delay)
+	return rehttp.NewTransport(base, retry(maxRetries), delay)
 }
 
-func retry(attempt rehttp.Attempt) bool {
-	if attempt.Response == nil {
-		return false
+func retry(maxRetries int) rehttp.RetryFn {
+	return func(attempt rehttp.Attempt) bool {
+		if attempt.Response == nil || attempt.Index >= maxRetries {
+			return false
+		}
+		switch attempt.Response.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		default:
+			return false
+		}
+
+		ctx := attempt.Request.Context()
+		if ctx.Err() != nil {
+			return false
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(maxDelay(attempt)).After(deadline) {
+			return false
+		}
+
+		return true
 	}
-	return attempt.Response.StatusCode == http.StatusTooManyRequests
 }
 
+// delay returns the time to wait before retrying the attempt. When the server
+// does not specify it, a random delay between half of maxDelay and maxDelay
+// is used in order to avoid concurrent clients retrying in lockstep.
 func delay(attempt rehttp.Attempt) time.Duration {
-	resetAt := attempt.Response.Header.Get("X-RateLimit-Reset")
-	resetAtUnix, err := strconv.ParseInt(resetAt, 10, 64)
-	if err != nil {
-		resetAtUnix = time.Now().Add(5 * time.Second).Unix()
+	if d, ok := retryAfter(attempt.Response.Header); ok {
+		return d
+	}
+
+	d := maxDelay(attempt)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// maxDelay returns the upper bound of the delay returned by delay.
+func maxDelay(attempt rehttp.Attempt) time.Duration {
+	if d, ok := retryAfter(attempt.Response.Header); ok {
+		return d
+	}
+
+	d := retryBaseDelay << uint(attempt.Index)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return d
+}
+
+// retryAfter returns the delay until the rate limit is reset as specified by
+// the "Retry-After" or "X-RateLimit-Reset" headers.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return nonNegative(time.Duration(seconds) * time.Second), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(time.Until(t)), true
+		}
+	}
+
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if resetAt, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return nonNegative(time.Duration(resetAt-time.Now().Unix()) * time.Second), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
 	}
-	return time.Duration(resetAtUnix-time.Now().Unix()) * time.Second
+	return d
 }
 
 // UserAgentTransport wraps base transport with a customized "User-Agent" header.
@@ -99,6 +189,51 @@ func DebugTransport(base http.RoundTripper, debug bool) http.RoundTripper {
 	})
 }
 
+// HookTransport wraps base transport, calling onRequest with every request
+// before it is sent and onResponse with every response received. Either hook
+// may be nil.
+//
+// The hooks are given copies, so reading their bodies does not affect the
+// request being sent or the response being returned. The value of the
+// "Authorization" header of the request copy is redacted.
+func HookTransport(base http.RoundTripper, onRequest func(*http.Request), onResponse func(*http.Response)) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if onRequest != nil {
+			r := req.Clone(req.Context())
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					r.Body = body
+				}
+			}
+			if r.Header.Get("Authorization") != "" {
+				r.Header.Set("Authorization", "[REDACTED]")
+			}
+			onRequest(r)
+		}
+
+		res, err := base.RoundTrip(req)
+		if err != nil || onResponse == nil {
+			return res, err
+		}
+
+		b, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+		r := *res
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+		onResponse(&r)
+
+		return res, nil
+	})
+}
+
 // Option is the type used to configure a client.
 type Option func(*http.Client)
 
@@ -116,6 +251,14 @@ func WithRateLimit() Option {
 	}
 }
 
+// WithRetries configures the client to enable rate limiting, retrying rate
+// limited requests at most maxRetries times.
+func WithRetries(maxRetries int) Option {
+	return func(c *http.Client) {
+		c.Transport = RetryTransport(c.Transport, maxRetries)
+	}
+}
+
 // WithUserAgent configures the client to overwrite the user agent header.
 func WithUserAgent(userAgent string) Option {
 	return func(c *http.Client) {
@@ -124,33 +267,146 @@ func WithUserAgent(userAgent string) Option {
 }
 
 // Wrap the base client with transports that enable OAuth2 authentication.
+//
+// The base client itself is left untouched. Its transport, redirect policy,
+// cookie jar and timeout are used by the returned client.
 func Wrap(base *http.Client, tokenSource oauth2.TokenSource, options ...Option) *http.Client {
 	if base == nil {
 		base = http.DefaultClient
 	}
 	client := &http.Client{
-		Timeout: base.Timeout,
+		Timeout:       base.Timeout,
+		CheckRedirect: base.CheckRedirect,
+		Jar:           base.Jar,
 		Transport: &oauth2.Transport{
 			Base:   base.Transport,
 			Source: tokenSource,
 		},
 	}
+	if s, ok := tokenSource.(*CachedTokenSource); ok {
+		client.Transport = refreshTransport(client.Transport, s)
+	}
 	for _, option := range options {
 		option(client)
 	}
 	return client
 }
 
+// refreshTransport wraps base transport, which authenticates requests using
+// the access tokens of s, to retry requests once with a new access token when
+// they fail with a 401 status code, e.g. because the cached access token was
+// revoked or expired earlier than expected.
+func refreshTransport(base http.RoundTripper, s *CachedTokenSource) http.RoundTripper {
+	return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		res, err := base.RoundTrip(req)
+		if err != nil || res.StatusCode != http.StatusUnauthorized {
+			return res, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return res, nil
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return res, nil
+			}
+			retry.Body = body
+		}
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+
+		s.Invalidate()
+		return base.RoundTrip(retry)
+	})
+}
+
 // OAuth2ClientCredentials sets the oauth2 client credentials.
-func OAuth2ClientCredentials(ctx context.Context, uri, clientID, clientSecret string) oauth2.TokenSource {
-	return (&clientcredentials.Config{
+//
+// The audience defaults to the Management API of uri if empty. The scopes,
+// if any, restrict the access token to a subset of the scopes granted to the
+// client.
+//
+// The returned token source caches the access token, refreshing it margin
+// before it expires, see CachedTokenSource.
+func OAuth2ClientCredentials(ctx context.Context, uri, clientID, clientSecret, audience string, scopes []string, margin time.Duration) *CachedTokenSource {
+	if audience == "" {
+		audience = uri + "/api/v2/"
+	}
+	cfg := &clientcredentials.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		TokenURL:     uri + "/oauth/token",
+		Scopes:       scopes,
 		EndpointParams: url.Values{
-			"audience": {uri + "/api/v2/"},
+			"audience": {audience},
 		},
-	}).TokenSource(ctx)
+	}
+	return NewCachedTokenSource(func() (*oauth2.Token, error) {
+		return cfg.Token(ctx)
+	}, margin)
+}
+
+// DefaultTokenExpiryMargin is how long before its expiry a cached access token
+// is refreshed, so that it doesn't expire while a request is in flight.
+const DefaultTokenExpiryMargin = time.Minute
+
+// CachedTokenSource is an oauth2.TokenSource which caches the access token
+// retrieved by a function, only retrieving a new one once the cached one is
+// about to expire. It is safe for concurrent use, retrieving at most one token
+// at a time.
+type CachedTokenSource struct {
+	retrieve func() (*oauth2.Token, error)
+	margin   time.Duration
+
+	mu    sync.Mutex
+	token *oauth2.Token
+	now   func() time.Time
+}
+
+// NewCachedTokenSource returns a CachedTokenSource using retrieve to obtain
+// access tokens, which are refreshed margin before they expire.
+func NewCachedTokenSource(retrieve func() (*oauth2.Token, error), margin time.Duration) *CachedTokenSource {
+	return &CachedTokenSource{retrieve: retrieve, margin: margin, now: time.Now}
+}
+
+// Token returns the cached access token, retrieving a new one if there is
+// none yet or if it expires within the margin.
+func (s *CachedTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && (s.token.Expiry.IsZero() || s.now().Add(s.margin).Before(s.token.Expiry)) {
+		return s.token, nil
+	}
+
+	t, err := s.retrieve()
+	if err != nil {
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+// Invalidate discards the cached access token, so that a new one is retrieved
+// by the next call to Token.
+func (s *CachedTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = nil
+}
+
+// Expiry returns the expiry of the cached access token, or the zero time if
+// no token has been retrieved yet or if it doesn't expire.
+func (s *CachedTokenSource) Expiry() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return time.Time{}
+	}
+	return s.token.Expiry
 }
 
 // StaticToken sets a static token to be used for oauth2.
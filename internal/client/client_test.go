@@ -1,11 +1,17 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/PuerkitoBio/rehttp"
+	"golang.org/x/oauth2"
 )
 
 func TestWrapRateLimit(t *testing.T) {
@@ -44,6 +50,153 @@ func TestWrapRateLimit(t *testing.T) {
 	}
 }
 
+func TestWrapRateLimitDeadline(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(10*time.Second).Unix()))
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", s.URL, nil)
+
+	start := time.Now()
+	c := Wrap(s.Client(), StaticToken(""), WithRateLimit())
+	r, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status code to be %d but got %d", http.StatusTooManyRequests, r.StatusCode)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the request to not be retried past its deadline, but it took %s", elapsed)
+	}
+}
+
+func TestWrapRetries(t *testing.T) {
+	requests := 0
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	c := Wrap(s.Client(), StaticToken(""), WithRetries(2))
+	r, err := c.Get(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status code to be %d but got %d", http.StatusTooManyRequests, r.StatusCode)
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests to be made but got %d", requests)
+	}
+}
+
+func TestWrapRetriesBody(t *testing.T) {
+	var bodies []string
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	c := Wrap(s.Client(), StaticToken(""), WithRetries(1))
+	r, err := c.Post(s.URL, "application/json", strings.NewReader(`{"foo":"bar"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code to be %d but got %d", http.StatusOK, r.StatusCode)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("Expected the request body to be sent twice but got %q", bodies)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+
+	for _, test := range []struct {
+		index    int
+		expected time.Duration
+	}{
+		{0, 500 * time.Millisecond},
+		{1, time.Second},
+		{3, 4 * time.Second},
+		{5, 10 * time.Second},
+		{100, 10 * time.Second},
+	} {
+		d := delay(rehttp.Attempt{Index: test.index, Response: res})
+		if d < test.expected/2 || d > test.expected {
+			t.Errorf("Expected delay of attempt %d to be between %s and %s but got %s", test.index, test.expected/2, test.expected, d)
+		}
+	}
+
+	res.Header.Set("Retry-After", "3")
+	if d := delay(rehttp.Attempt{Response: res}); d != 3*time.Second {
+		t.Errorf("Expected delay to be %s but got %s", 3*time.Second, d)
+	}
+}
+
+func TestWrapRetriesDeadlineBackoff(t *testing.T) {
+	requests := 0
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	// The first retry waits between 250ms and 500ms, which could exceed the
+	// deadline, so no retry must be attempted.
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", s.URL, nil)
+
+	c := Wrap(s.Client(), StaticToken(""), WithRetries(1))
+	r, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code to be %d but got %d", http.StatusServiceUnavailable, r.StatusCode)
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request to be made but got %d", requests)
+	}
+}
+
 func TestWrapUserAgent(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ua := r.Header.Get("User-Agent")
@@ -58,3 +211,61 @@ func TestWrapUserAgent(t *testing.T) {
 	c := Wrap(s.Client(), StaticToken(""), WithUserAgent(UserAgent))
 	c.Get(s.URL)
 }
+
+func TestWrapPreservesClient(t *testing.T) {
+	errRedirect := fmt.Errorf("redirect")
+	base := &http.Client{
+		Timeout:       time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return errRedirect },
+	}
+	c := Wrap(base, StaticToken(""))
+
+	if c.Timeout != base.Timeout {
+		t.Errorf("expected timeout %s, got %s", base.Timeout, c.Timeout)
+	}
+	if c.CheckRedirect == nil || c.CheckRedirect(nil, nil) != errRedirect {
+		t.Error("expected the redirect policy of the base client to be used")
+	}
+}
+
+func TestCachedTokenSource(t *testing.T) {
+	now := time.Now()
+	var calls int
+	s := NewCachedTokenSource(func() (*oauth2.Token, error) {
+		calls++
+		return &oauth2.Token{
+			AccessToken: fmt.Sprintf("token-%d", calls),
+			Expiry:      now.Add(5 * time.Minute),
+		}, nil
+	}, DefaultTokenExpiryMargin)
+	s.now = func() time.Time { return now }
+
+	if !s.Expiry().IsZero() {
+		t.Errorf("expected no expiry before the first token, got %s", s.Expiry())
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := s.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "token-1" {
+			t.Errorf("expected the cached token to be returned, got %q", token.AccessToken)
+		}
+	}
+	if !s.Expiry().Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("expected the expiry of the cached token, got %s", s.Expiry())
+	}
+
+	now = now.Add(4*time.Minute + 30*time.Second)
+	token, err := s.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "token-2" {
+		t.Errorf("expected the token to be refreshed within the margin, got %q", token.AccessToken)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 tokens to be retrieved, got %d", calls)
+	}
+}
@@ -105,6 +105,17 @@ type Action struct {
 type ActionList struct {
 	List
 	Actions []*Action `json:"actions"`
+
+	// The page of the results, starting at 0.
+	Page int `json:"page"`
+	// The maximum amount of actions per page.
+	PerPage int `json:"per_page"`
+}
+
+// HasNext returns true if the list has more results. Unlike other lists, the
+// list of actions is described by its page rather than its start and limit.
+func (l ActionList) HasNext() bool {
+	return l.Total > (l.Page+1)*l.PerPage
 }
 
 // ActionVersion is used to manage Actions version history.
@@ -255,8 +266,23 @@ func (m *ActionManager) Delete(id string, opts ...RequestOption) error {
 	return m.Request("DELETE", m.URI("actions", "actions", id), nil, opts...)
 }
 
+// TriggerID configures ActionManager.List to only list the actions bound to
+// the trigger with the given id, e.g. "post-login".
+func TriggerID(id string) RequestOption {
+	return Parameter("triggerId", id)
+}
+
+// ActionName configures ActionManager.List to only list the actions with the
+// given name.
+func ActionName(name string) RequestOption {
+	return Parameter("actionName", name)
+}
+
 // List all actions.
 //
+// The actions can be filtered using the TriggerID and ActionName options, and
+// paginated using the Page and PerPage options.
+//
 // See: https://auth0.com/docs/api/management/v2#!/Actions/get_actions
 func (m *ActionManager) List(opts ...RequestOption) (l *ActionList, err error) {
 	err = m.Request("GET", m.URI("actions", "actions"), &l, applyActionsListDefaults(opts))
@@ -315,6 +341,10 @@ func (m *ActionManager) DeployVersion(id string, versionID string, opts ...Reque
 
 // Test an action.
 //
+// On success, the result of the test as returned by the API is stored in
+// payload. Test can be used to validate an action, e.g. in a CI pipeline,
+// before it is deployed using Deploy.
+//
 // See: https://auth0.com/docs/api/management/v2/#!/Actions/post_test_action
 func (m *ActionManager) Test(id string, payload *ActionTestPayload, opts ...RequestOption) (err error) {
 	r := &actionTestRequest{
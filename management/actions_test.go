@@ -2,10 +2,14 @@ package management
 
 import (
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func ensureActionBuilt(a *Action) (err error) {
@@ -198,7 +202,7 @@ func TestActions(t *testing.T) {
 	t.Run("Execution", func(t *testing.T) {
 		_, err := m.Action.Execution("M9IqRp9wQLaYNrSwz6YPTTIwMjEwNDA0")
 		if err != nil {
-			mgmtError, _ := err.(*managementError)
+			mgmtError, _ := err.(*ManagementError)
 			if mgmtError.StatusCode != 404 {
 				t.Fatal(err)
 			}
@@ -225,3 +229,68 @@ func TestActions(t *testing.T) {
 		}
 	})
 }
+
+func TestActionManagerTestAndDeploy(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "POST")
+		switch r.URL.Path {
+		case "/api/v2/actions/actions/act_123/test":
+			b, _ := ioutil.ReadAll(r.Body)
+			expect.Expect(t, string(b), `{"payload":{"event":{"user":{"email":"jane@example.com"}}}}`+"\n")
+			w.Write([]byte(`{"payload":{"logs":"ok","stats":{"total_request_duration_ms":10}}}`))
+		case "/api/v2/actions/actions/act_123/deploy":
+			w.Write([]byte(`{"id":"ver_123","deployed":true}`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := ActionTestPayload{"event": map[string]interface{}{
+		"user": map[string]interface{}{"email": "jane@example.com"},
+	}}
+	if err := m.Action.Test("act_123", &payload); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, payload["logs"], "ok")
+
+	v, err := m.Action.Deploy("act_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, v.GetID(), "ver_123")
+	expect.Expect(t, v.Deployed, true)
+}
+
+func TestActionManagerListFilter(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		expect.Expect(t, q.Get("triggerId"), "post-login")
+		expect.Expect(t, q.Get("actionName"), "foo")
+		expect.Expect(t, q.Get("per_page"), "1")
+		w.Write([]byte(`{"actions":[{"id":"act_1","name":"foo"}],"total":2,"page":0,"per_page":1}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := m.Action.List(TriggerID("post-login"), ActionName("foo"), PerPage(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, len(l.Actions), 1)
+	expect.Expect(t, l.Total, 2)
+	expect.Expect(t, l.PerPage, 1)
+	expect.Expect(t, l.HasNext(), true)
+
+	l.Page = 1
+	expect.Expect(t, l.HasNext(), false)
+}
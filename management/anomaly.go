@@ -38,7 +38,7 @@ func (m *AnomalyManager) CheckIP(ip string, opts ...RequestOption) (isBlocked bo
 		return false, nil
 	}
 
-	return false, newError(res.Body)
+	return false, newError(res)
 }
 
 // UnblockIP unblocks an IP address currently blocked by the multiple
@@ -47,7 +47,7 @@ func (m *AttackProtectionManager) GetBreachedPasswordDetection(
 
 // UpdateBreachedPasswordDetection updates the breached password detection settings.
 //
-// Required scope: `read:attack_protection`
+// Required scope: `update:attack_protection`
 //
 // See: https://auth0.com/docs/api/management/v2#!/Attack_Protection/patch_breached_password_detection
 func (m *AttackProtectionManager) UpdateBreachedPasswordDetection(
@@ -95,7 +95,7 @@ func (m *AttackProtectionManager) GetBruteForceProtection(
 
 // UpdateBruteForceProtection updates the brute force configuration.
 //
-// Required scope: `read:attack_protection`
+// Required scope: `update:attack_protection`
 //
 // See: https://auth0.com/docs/api/management/v2#!/Attack_Protection/patch_brute_force_protection
 func (m *AttackProtectionManager) UpdateBruteForceProtection(
@@ -161,7 +161,7 @@ func (m *AttackProtectionManager) GetSuspiciousIPThrottling(
 
 // UpdateSuspiciousIPThrottling updates the suspicious IP throttling configuration.
 //
-// Required scope: `read:attack_protection`
+// Required scope: `update:attack_protection`
 //
 // See: https://auth0.com/docs/api/management/v2#!/Attack_Protection/patch_suspicious_ip_throttling
 func (m *AttackProtectionManager) UpdateSuspiciousIPThrottling(
@@ -36,7 +36,7 @@ func newBlacklistManager(m *Management) *BlacklistManager {
 //
 // See: https://auth0.com/docs/api/management/v2#!/Blacklists/get_tokens
 func (m *BlacklistManager) List(opts ...RequestOption) (bl []*BlacklistToken, err error) {
-	err = m.Request("GET", m.URI("blacklists", "tokens"), &bl, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("blacklists", "tokens"), &bl, applyListDefaults(opts)...)
 	return
 }
 
@@ -2,8 +2,10 @@ package management
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 )
 
 // Branding is used to customize the look and feel of Auth0 to align
@@ -132,6 +134,32 @@ type BrandingUniversalLogin struct {
 	Body *string `json:"body,omitempty"`
 }
 
+var (
+	brandingUniversalLoginHead   = regexp.MustCompile(`{%-?\s*auth0:head\s*-?%}`)
+	brandingUniversalLoginWidget = regexp.MustCompile(`{%-?\s*auth0:widget\s*-?%}`)
+)
+
+// Validate checks that the Body of the template contains the
+// "{%- auth0:head -%}" and "{%- auth0:widget -%}" tags, as templates without
+// them are rejected by the Auth0 Management API.
+func (ul *BrandingUniversalLogin) Validate() error {
+	if ul.Body == nil {
+		return errors.New("the universal login template body must be set")
+	}
+	for _, tag := range []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"{%- auth0:head -%}", brandingUniversalLoginHead},
+		{"{%- auth0:widget -%}", brandingUniversalLoginWidget},
+	} {
+		if !tag.re.MatchString(*ul.Body) {
+			return fmt.Errorf("the universal login template body must contain the %q tag", tag.name)
+		}
+	}
+	return nil
+}
+
 // BrandingManager manages Auth0 Branding resources.
 type BrandingManager struct {
 	*Management
@@ -165,9 +193,15 @@ func (m *BrandingManager) UniversalLogin(opts ...RequestOption) (ul *BrandingUni
 }
 
 // SetUniversalLogin sets the template for the New Universal Login Experience.
+// The template is checked using BrandingUniversalLogin.Validate before being
+// sent.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Branding/put_universal_login
 func (m *BrandingManager) SetUniversalLogin(ul *BrandingUniversalLogin, opts ...RequestOption) (err error) {
+	if err := ul.Validate(); err != nil {
+		return err
+	}
+
 	req, err := m.NewRequest("PUT", m.URI("branding", "templates", "universal-login"), ul.Body, opts...)
 	if err != nil {
 		return err
@@ -177,9 +211,10 @@ func (m *BrandingManager) SetUniversalLogin(ul *BrandingUniversalLogin, opts ...
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode >= http.StatusBadRequest {
-		return newError(res.Body)
+		return newError(res)
 	}
 
 	return nil
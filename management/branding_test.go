@@ -170,3 +170,25 @@ func TestBrandingColors(t *testing.T) {
 		})
 	}
 }
+
+func TestBrandingUniversalLoginValidate(t *testing.T) {
+	for _, test := range []struct {
+		body  *string
+		valid bool
+	}{
+		{auth0.String(`<html><head>{%- auth0:head -%}</head><body>{%- auth0:widget -%}</body></html>`), true},
+		{auth0.String(`<html><head>{% auth0:head %}</head><body>{%auth0:widget%}</body></html>`), true},
+		{auth0.String(`<html><head>{%- auth0:head -%}</head><body></body></html>`), false},
+		{auth0.String(`<html><head></head><body>{%- auth0:widget -%}</body></html>`), false},
+		{auth0.String(``), false},
+		{nil, false},
+	} {
+		err := (&BrandingUniversalLogin{Body: test.body}).Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("unexpected validation result for %q: %v", auth0.StringValue(test.body), err)
+		}
+	}
+
+	err := m.Branding.SetUniversalLogin(&BrandingUniversalLogin{Body: auth0.String("<html></html>")})
+	expect.Expect(t, err.Error(), `the universal login template body must contain the "{%- auth0:head -%}" tag`)
+}
@@ -182,10 +182,47 @@ func (m *ClientManager) Read(id string, opts ...RequestOption) (c *Client, err e
 //
 // See: https://auth0.com/docs/api/management/v2#!/Clients/get_clients
 func (m *ClientManager) List(opts ...RequestOption) (c *ClientList, err error) {
-	err = m.Request("GET", m.URI("clients"), &c, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("clients"), &c, applyListDefaults(opts)...)
 	return
 }
 
+// ListAll returns an iterator over all clients, transparently requesting
+// additional pages as needed. The request options are applied to every page
+// request.
+func (m *ClientManager) ListAll(opts ...RequestOption) *ClientIterator {
+	it := &ClientIterator{}
+	it.pager = NewPager(func(opts ...RequestOption) (int, error) {
+		l, err := m.List(opts...)
+		if err != nil {
+			return 0, err
+		}
+		it.clients = l.Clients
+		return len(l.Clients), nil
+	}, opts...)
+	return it
+}
+
+// ClientIterator iterates over all clients.
+type ClientIterator struct {
+	pager   *Pager
+	clients []*Client
+}
+
+// Next returns the next client. Once all clients have been returned it returns
+// ErrIteratorDone, or the error of the failed request if any.
+func (it *ClientIterator) Next() (*Client, error) {
+	for len(it.clients) == 0 {
+		if !it.pager.Next() {
+			return nil, it.pager.done()
+		}
+	}
+
+	c := it.clients[0]
+	it.clients = it.clients[1:]
+
+	return c, nil
+}
+
 // Update a client.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Clients/patch_clients_by_id
@@ -193,7 +230,11 @@ func (m *ClientManager) Update(id string, c *Client, opts ...RequestOption) (err
 	return m.Request("PATCH", m.URI("clients", id), c, opts...)
 }
 
-// RotateSecret rotates a client secret.
+// RotateSecret rotates a client secret, returning the client with its new
+// ClientSecret populated.
+//
+// Note: The previous secret is invalidated immediately, so any application
+// still using it will fail to authenticate until it is updated.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Clients/post_rotate_secret
 func (m *ClientManager) RotateSecret(id string, opts ...RequestOption) (c *Client, err error) {
@@ -60,7 +60,7 @@ func (m *ClientGrantManager) Read(id string, opts ...RequestOption) (*ClientGran
 		}
 		page++
 	}
-	return nil, &managementError{
+	return nil, &ManagementError{
 		StatusCode: 404,
 		Err:        "Not Found",
 		Message:    "Client grant not found",
@@ -81,13 +81,27 @@ func (m *ClientGrantManager) Delete(id string, opts ...RequestOption) (err error
 	return m.Request("DELETE", m.URI("client-grants", id), nil, opts...)
 }
 
+// Audience configures ClientGrantManager.List and GrantManager.List to only
+// list the grants for the API with the given audience, i.e. its identifier.
+func Audience(audience string) RequestOption {
+	return Parameter("audience", audience)
+}
+
+// ClientID configures ClientGrantManager.List, GrantManager.List and
+// DeviceCredentialManager.List to only list the grants or device credentials
+// of the client with the given id.
+func ClientID(id string) RequestOption {
+	return Parameter("client_id", id)
+}
+
 // List all client grants.
 //
 // This method forces the `include_totals=true` and defaults to `per_page=50` if
-// not provided.
+// not provided. The client grants can be filtered using the Audience and
+// ClientID options.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Client_Grants/get_client_grants
 func (m *ClientGrantManager) List(opts ...RequestOption) (gs *ClientGrantList, err error) {
-	err = m.Request("GET", m.URI("client-grants"), &gs, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("client-grants"), &gs, applyListDefaults(opts)...)
 	return
 }
@@ -1,10 +1,14 @@
 package management
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestClientGrant(t *testing.T) {
@@ -98,3 +102,27 @@ func TestClientGrant(t *testing.T) {
 		t.Logf("%v\n", gs)
 	})
 }
+
+func TestClientGrantManagerListFilters(t *testing.T) {
+	var query url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"client_grants":[{"id":"cgr_123","client_id":"client_1","audience":"https://api.example.com"}],"start":0,"limit":50,"total":1}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := m.ClientGrant.List(Audience("https://api.example.com"), ClientID("client_1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, query.Get("audience"), "https://api.example.com")
+	expect.Expect(t, query.Get("client_id"), "client_1")
+	expect.Expect(t, query.Get("include_totals"), "true")
+	expect.Expect(t, l.Total, 1)
+	expect.Expect(t, l.ClientGrants[0].GetID(), "cgr_123")
+}
@@ -2,6 +2,7 @@ package management
 
 import (
 	"encoding/json"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -862,10 +863,55 @@ func (m *ConnectionManager) Read(id string, opts ...RequestOption) (c *Connectio
 //
 // See: https://auth0.com/docs/api/management/v2#!/Connections/get_connections
 func (m *ConnectionManager) List(opts ...RequestOption) (c *ConnectionList, err error) {
-	err = m.Request("GET", m.URI("connections"), &c, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("connections"), &c, applyListDefaults(opts)...)
 	return
 }
 
+// Count returns the amount of connections matching the given options, e.g.
+// Strategy, without retrieving any of them.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Connections/get_connections
+func (m *ConnectionManager) Count(opts ...RequestOption) (int, error) {
+	return m.count(m.URI("connections"), opts)
+}
+
+// ListAll returns an iterator over all connections, transparently requesting
+// additional pages as needed. The request options are applied to every page
+// request.
+func (m *ConnectionManager) ListAll(opts ...RequestOption) *ConnectionIterator {
+	it := &ConnectionIterator{}
+	it.pager = NewPager(func(opts ...RequestOption) (int, error) {
+		l, err := m.List(opts...)
+		if err != nil {
+			return 0, err
+		}
+		it.connections = l.Connections
+		return len(l.Connections), nil
+	}, opts...)
+	return it
+}
+
+// ConnectionIterator iterates over all connections.
+type ConnectionIterator struct {
+	pager       *Pager
+	connections []*Connection
+}
+
+// Next returns the next connection. Once all connections have been returned it returns
+// ErrIteratorDone, or the error of the failed request if any.
+func (it *ConnectionIterator) Next() (*Connection, error) {
+	for len(it.connections) == 0 {
+		if !it.pager.Next() {
+			return nil, it.pager.done()
+		}
+	}
+
+	c := it.connections[0]
+	it.connections = it.connections[1:]
+
+	return c, nil
+}
+
 // Update a connection.
 //
 // Note: if you use the options' parameter, the whole options object will be
@@ -883,11 +929,20 @@ func (m *ConnectionManager) Delete(id string, opts ...RequestOption) (err error)
 	return m.Request("DELETE", m.URI("connections", id), nil, opts...)
 }
 
+// Strategy configures ConnectionManager.List and ConnectionManager.ReadByName
+// to only return connections using one of the given strategies, e.g. "auth0".
+func Strategy(strategies ...string) RequestOption {
+	return Parameters(url.Values{"strategy": strategies})
+}
+
 // ReadByName retrieves a connection by its name. This is a helper method when a
 // connection id is not readily available.
+//
+// The lookup can be narrowed down using the Strategy option. A 404
+// *ManagementError is returned if no connection matches.
 func (m *ConnectionManager) ReadByName(name string, opts ...RequestOption) (*Connection, error) {
 	if name == "" {
-		return nil, &managementError{400, "Bad Request", "Name cannot be empty"}
+		return nil, &ManagementError{StatusCode: 400, Err: "Bad Request", Message: "Name cannot be empty"}
 	}
 	c, err := m.List(append(opts, Parameter("name", name))...)
 	if err != nil {
@@ -896,5 +951,62 @@ func (m *ConnectionManager) ReadByName(name string, opts ...RequestOption) (*Con
 	if len(c.Connections) > 0 {
 		return c.Connections[0], nil
 	}
-	return nil, &managementError{404, "Not Found", "Connection not found"}
+	return nil, &ManagementError{StatusCode: 404, Err: "Not Found", Message: "Connection not found"}
+}
+
+// EnableClient enables the connection for the client with the given id.
+//
+// The enabled clients of the connection are read, updated and sent back in a
+// PATCH operation. Enabling a client for which the connection is already
+// enabled is a no-op. Note that concurrent changes to the enabled clients of
+// the same connection may be overwritten.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Connections/patch_connections_by_id
+func (m *ConnectionManager) EnableClient(id, clientID string, opts ...RequestOption) error {
+	return m.setClientEnabled(id, clientID, true, opts...)
+}
+
+// DisableClient disables the connection for the client with the given id.
+//
+// The enabled clients of the connection are read, updated and sent back in a
+// PATCH operation. Disabling a client for which the connection is not enabled
+// is a no-op. Note that concurrent changes to the enabled clients of the same
+// connection may be overwritten.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Connections/patch_connections_by_id
+func (m *ConnectionManager) DisableClient(id, clientID string, opts ...RequestOption) error {
+	return m.setClientEnabled(id, clientID, false, opts...)
+}
+
+func (m *ConnectionManager) setClientEnabled(id, clientID string, enabled bool, opts ...RequestOption) error {
+	c, err := m.Read(id, opts...)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	clients := make([]interface{}, 0, len(c.EnabledClients)+1)
+	for _, ec := range c.EnabledClients {
+		if ec == clientID {
+			found = true
+			if !enabled {
+				continue
+			}
+		}
+		clients = append(clients, ec)
+	}
+	if found == enabled {
+		return nil
+	}
+	if enabled {
+		clients = append(clients, clientID)
+	}
+
+	// The enabled clients are sent without omitempty, so that disabling the
+	// last client sends an empty array instead of leaving them unchanged.
+	payload := &struct {
+		EnabledClients []interface{} `json:"enabled_clients"`
+	}{clients}
+
+	return m.Request("PATCH", m.URI("connections", id), payload, opts...)
 }
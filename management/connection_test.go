@@ -1,7 +1,11 @@
 package management
 
 import (
+	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -138,7 +142,7 @@ func TestConnection(t *testing.T) {
 		if err == nil {
 			t.Fail()
 		}
-		mgmtError, ok := err.(*managementError)
+		mgmtError, ok := err.(*ManagementError)
 		if !ok {
 			t.Fail()
 		}
@@ -637,3 +641,82 @@ func assertDeleted(t *testing.T, c *Connection) {
 	assert.Nil(t, c)
 	assert.EqualError(t, err, "404 Not Found: The connection does not exist")
 }
+
+func TestConnectionManagerEnableClient(t *testing.T) {
+	var patches []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":"con_123","enabled_clients":["client_1","client_2"]}`))
+		case "PATCH":
+			b, _ := ioutil.ReadAll(r.Body)
+			patches = append(patches, string(b))
+			w.Write([]byte(`{"id":"con_123"}`))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		enable   bool
+		clientID string
+		patch    string
+	}{
+		{true, "client_3", `{"enabled_clients":["client_1","client_2","client_3"]}` + "\n"},
+		{true, "client_1", ""},
+		{false, "client_1", `{"enabled_clients":["client_2"]}` + "\n"},
+		{false, "client_3", ""},
+	} {
+		patches = nil
+		if test.enable {
+			err = m.Connection.EnableClient("con_123", test.clientID)
+		} else {
+			err = m.Connection.DisableClient("con_123", test.clientID)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.patch == "" {
+			expect.Expect(t, len(patches), 0)
+			continue
+		}
+		expect.Expect(t, patches, []string{test.patch})
+	}
+}
+
+func TestConnectionManagerReadByName(t *testing.T) {
+	var query url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if query.Get("name") == "missing" {
+			w.Write([]byte(`{"connections":[]}`))
+			return
+		}
+		w.Write([]byte(`{"connections":[{"id":"con_123","name":"my-db","strategy":"auth0"}]}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := m.Connection.ReadByName("my-db", Strategy(ConnectionStrategyAuth0, ConnectionStrategyAD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, c.GetID(), "con_123")
+	expect.Expect(t, query.Get("name"), "my-db")
+	expect.Expect(t, query["strategy"], []string{"auth0", "ad"})
+
+	_, err = m.Connection.ReadByName("missing")
+	if mErr, ok := err.(*ManagementError); !ok || mErr.Status() != http.StatusNotFound {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package management
+
+const (
+	// DeviceCredentialTypePublicKey constant.
+	DeviceCredentialTypePublicKey = "public_key"
+	// DeviceCredentialTypeRefreshToken constant.
+	DeviceCredentialTypeRefreshToken = "refresh_token"
+	// DeviceCredentialTypeRotatingRefreshToken constant.
+	DeviceCredentialTypeRotatingRefreshToken = "rotating_refresh_token"
+)
+
+// DeviceCredential is a credential issued to a device, such as a refresh
+// token or a public key.
+//
+// See: https://auth0.com/docs/secure/tokens/refresh-tokens/manage-refresh-tokens
+type DeviceCredential struct {
+	// The id of the device credential.
+	ID *string `json:"id,omitempty"`
+
+	// The name of the device the credential was issued to, e.g. "iPhone".
+	DeviceName *string `json:"device_name,omitempty"`
+
+	// The unique identifier of the device the credential was issued to.
+	DeviceID *string `json:"device_id,omitempty"`
+
+	// The type of the credential, one of the DeviceCredentialType constants.
+	Type *string `json:"type,omitempty"`
+
+	// The id of the user the credential belongs to.
+	UserID *string `json:"user_id,omitempty"`
+
+	// The id of the client the credential was issued for.
+	ClientID *string `json:"client_id,omitempty"`
+
+	// The base64 encoded public key, only set when creating a credential of
+	// type "public_key".
+	Value *string `json:"value,omitempty"`
+}
+
+// DeviceCredentialList is a list of DeviceCredentials.
+type DeviceCredentialList struct {
+	List
+	DeviceCredentials []*DeviceCredential `json:"device_credentials"`
+}
+
+// DeviceCredentialManager manages Auth0 DeviceCredential resources.
+type DeviceCredentialManager struct {
+	*Management
+}
+
+func newDeviceCredentialManager(m *Management) *DeviceCredentialManager {
+	return &DeviceCredentialManager{m}
+}
+
+// DeviceCredentialType configures DeviceCredentialManager.List to only list
+// the device credentials of the given type, e.g.
+// DeviceCredentialTypeRefreshToken.
+func DeviceCredentialType(t string) RequestOption {
+	return Parameter("type", t)
+}
+
+// List device credentials.
+//
+// The device credentials can be filtered using the UserID, ClientID and
+// DeviceCredentialType options. This method forces the `include_totals=true`
+// and defaults to `per_page=50` if not provided.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Device_Credentials/get_device_credentials
+func (m *DeviceCredentialManager) List(opts ...RequestOption) (l *DeviceCredentialList, err error) {
+	err = m.Request("GET", m.URI("device-credentials"), &l, applyListDefaults(opts)...)
+	return
+}
+
+// Create a device public key credential.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Device_Credentials/post_device_credentials
+func (m *DeviceCredentialManager) Create(d *DeviceCredential, opts ...RequestOption) error {
+	return m.Request("POST", m.URI("device-credentials"), d, opts...)
+}
+
+// Delete a device credential, e.g. to revoke a refresh token.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Device_Credentials/delete_device_credentials_by_id
+func (m *DeviceCredentialManager) Delete(id string, opts ...RequestOption) error {
+	return m.Request("DELETE", m.URI("device-credentials", id), nil, opts...)
+}
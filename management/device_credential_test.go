@@ -0,0 +1,44 @@
+package management
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/auth0/go-auth0/internal/testing/expect"
+)
+
+func TestDeviceCredentialManagerList(t *testing.T) {
+	var query url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"device_credentials":[{"id":"dcr_123","device_name":"iPhone","type":"refresh_token","user_id":"auth0|123"}],"start":0,"limit":50,"total":1}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := m.DeviceCredential.List(
+		UserID("auth0|123"),
+		ClientID("client_1"),
+		DeviceCredentialType(DeviceCredentialTypeRefreshToken),
+		Page(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, query, url.Values{
+		"user_id":        {"auth0|123"},
+		"client_id":      {"client_1"},
+		"type":           {"refresh_token"},
+		"page":           {"1"},
+		"per_page":       {"50"},
+		"include_totals": {"true"},
+	})
+	expect.Expect(t, l.Total, 1)
+	expect.Expect(t, l.DeviceCredentials[0].GetID(), "dcr_123")
+	expect.Expect(t, l.DeviceCredentials[0].GetType(), DeviceCredentialTypeRefreshToken)
+}
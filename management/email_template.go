@@ -1,5 +1,7 @@
 package management
 
+import "errors"
+
 // EmailTemplate is used to customize emails.
 //
 // See https://auth0.com/docs/customize/email/email-templates
@@ -76,3 +78,19 @@ func (m *EmailTemplateManager) Update(template string, e *EmailTemplate, opts ..
 func (m *EmailTemplateManager) Replace(template string, e *EmailTemplate, opts ...RequestOption) (err error) {
 	return m.Request("PUT", m.URI("email-templates", template), e, opts...)
 }
+
+// Upsert updates the email template named by the Template field of e,
+// creating it if it doesn't exist yet.
+//
+// As the API responds to the update of a missing template with either a 404 or
+// a 409 status code, both cause the template to be created instead.
+func (m *EmailTemplateManager) Upsert(e *EmailTemplate, opts ...RequestOption) error {
+	if e.GetTemplate() == "" {
+		return errors.New("email template name is required")
+	}
+	err := m.Update(e.GetTemplate(), e, opts...)
+	if IsNotFound(err) || IsConflict(err) {
+		return m.Create(e, opts...)
+	}
+	return err
+}
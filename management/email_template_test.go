@@ -2,9 +2,11 @@ package management
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestEmailTemplate(t *testing.T) {
@@ -84,3 +86,39 @@ func TestEmailTemplate(t *testing.T) {
 		}
 	})
 }
+
+func TestEmailTemplateManagerUpsert(t *testing.T) {
+	for _, test := range []struct {
+		updateStatus int
+		methods      []string
+	}{
+		{http.StatusOK, []string{"PATCH"}},
+		{http.StatusNotFound, []string{"PATCH", "POST"}},
+		{http.StatusConflict, []string{"PATCH", "POST"}},
+	} {
+		var methods []string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			if r.Method == "PATCH" && test.updateStatus != http.StatusOK {
+				w.WriteHeader(test.updateStatus)
+				w.Write([]byte(`{}`))
+				return
+			}
+			w.Write([]byte(`{"template":"welcome_email"}`))
+		}))
+
+		m, err := New(s.URL, WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = m.EmailTemplate.Upsert(&EmailTemplate{
+			Template: auth0.String("welcome_email"),
+			Body:     auth0.String("<html>Welcome</html>"),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		expect.Expect(t, methods, test.methods)
+		s.Close()
+	}
+}
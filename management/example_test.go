@@ -172,3 +172,20 @@ func ExampleConnectionManager_Create() {
 		// handle err
 	}
 }
+
+func ExampleManagement_Call() {
+	// A hypothetical endpoint which is not yet supported by the SDK.
+	type widget struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name"`
+	}
+
+	var w widget
+	err := api.Call("POST", "widgets", &widget{Name: "example"}, &w,
+		management.Parameter("dry_run", "true"),
+	)
+	if err != nil {
+		// handle err
+	}
+	fmt.Println(w.ID)
+}
@@ -40,6 +40,8 @@ var (
 	blacklist = []string{
 		`Management`,
 		`.*Manager`,
+		`.*Iterator`,
+		`Pager`,
 	}
 )
 
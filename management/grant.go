@@ -1,5 +1,9 @@
 package management
 
+import (
+	"fmt"
+)
+
 // Grant is a way of retrieving an Access Token.
 //
 // See: https://auth0.com/docs/get-started/authentication-and-authorization-flow/which-oauth-2-0-flow-should-i-use
@@ -34,11 +38,19 @@ func newGrantManager(m *Management) *GrantManager {
 	return &GrantManager{m}
 }
 
+// UserID configures GrantManager.List and DeviceCredentialManager.List to only
+// list the grants or device credentials of the user with the given id.
+func UserID(id string) RequestOption {
+	return Parameter("user_id", id)
+}
+
 // List the grants associated with your account.
 //
+// The grants can be filtered using the UserID, ClientID and Audience options.
+//
 // See: https://auth0.com/docs/api/management/v2#!/Grants/get_grants
 func (m *GrantManager) List(opts ...RequestOption) (g *GrantList, err error) {
-	err = m.Request("GET", m.URI("grants"), &g, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("grants"), &g, applyListDefaults(opts)...)
 	return
 }
 
@@ -48,3 +60,33 @@ func (m *GrantManager) List(opts ...RequestOption) (g *GrantList, err error) {
 func (m *GrantManager) Delete(id string, opts ...RequestOption) error {
 	return m.Request("DELETE", m.URI("grants", id), nil, opts...)
 }
+
+// DeleteByUser revokes all the grants of the user with the given id, e.g. to
+// log them out of every application.
+//
+// The grants are listed first and then deleted one by one, returning every
+// failure; use WithConcurrency to delete several grants in parallel.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Grants/delete_grants_by_id
+func (m *GrantManager) DeleteByUser(userID string, opts ...RequestOption) error {
+	var ids []string
+	for page := 0; ; page++ {
+		l, err := m.List(append(opts, UserID(userID), Page(page))...)
+		if err != nil {
+			return err
+		}
+		for _, g := range l.Grants {
+			ids = append(ids, g.GetID())
+		}
+		if !l.HasNext() {
+			break
+		}
+	}
+
+	return forEachConcurrently(len(ids), opts, func(i int) error {
+		if err := m.Delete(ids[i], opts...); err != nil {
+			return fmt.Errorf("deleting grant %q failed: %w", ids[i], err)
+		}
+		return nil
+	})
+}
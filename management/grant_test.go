@@ -1,6 +1,17 @@
 package management
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/auth0/go-auth0/internal/testing/expect"
+)
 
 func TestGrant(t *testing.T) {
 	var err error
@@ -14,3 +25,46 @@ func TestGrant(t *testing.T) {
 		t.Logf("%v\n", gs)
 	})
 }
+
+func TestGrantManagerDeleteByUser(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			id := strings.TrimPrefix(r.URL.Path, "/api/v2/grants/")
+			if id == "gr_3" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		expect.Expect(t, r.URL.Query().Get("user_id"), "auth0|123")
+		switch r.URL.Query().Get("page") {
+		case "0":
+			fmt.Fprint(w, `{"grants":[{"id":"gr_1"},{"id":"gr_2"}],"start":0,"limit":2,"total":3}`)
+		default:
+			fmt.Fprint(w, `{"grants":[{"id":"gr_3"}],"start":2,"limit":2,"total":3}`)
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Grant.DeleteByUser("auth0|123", PerPage(2), WithConcurrency(2))
+	var mErr *ManagementError
+	if !errors.As(err, &mErr) || mErr.Status() != http.StatusInternalServerError {
+		t.Fatalf("expected a 500 error, got %v", err)
+	}
+	expect.Expect(t, strings.Contains(err.Error(), `deleting grant "gr_3" failed`), true)
+
+	sort.Strings(deleted)
+	expect.Expect(t, deleted, []string{"gr_1", "gr_2"})
+}
@@ -2,6 +2,7 @@ package management
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -36,9 +37,34 @@ type MultiFactor struct {
 	TrialExpired *bool `json:"trial_expired,omitempty"`
 }
 
+const (
+	// MultiFactorPolicyAllApplications requires MultiFactor authentication for
+	// all applications.
+	MultiFactorPolicyAllApplications = "all-applications"
+	// MultiFactorPolicyConfidenceScore requires MultiFactor authentication
+	// only for risky logins, as determined by Adaptive MFA.
+	MultiFactorPolicyConfidenceScore = "confidence-score"
+)
+
 // MultiFactorPolicies policies for MultiFactor authentication.
+//
+// Empty policies mean that MultiFactor authentication is never required.
 type MultiFactorPolicies []string
 
+// validate checks that every policy is one of MultiFactorPolicyAllApplications
+// and MultiFactorPolicyConfidenceScore.
+func (p MultiFactorPolicies) validate() error {
+	for _, policy := range p {
+		switch policy {
+		case MultiFactorPolicyAllApplications, MultiFactorPolicyConfidenceScore:
+		default:
+			return fmt.Errorf("invalid MultiFactor policy %q, must be one of %q or %q",
+				policy, MultiFactorPolicyAllApplications, MultiFactorPolicyConfidenceScore)
+		}
+	}
+	return nil
+}
+
 // MultiFactorProvider holds provider type for MultiFactor Authentication.
 type MultiFactorProvider struct {
 	// One of auth0|twilio|phone-message-hook
@@ -156,7 +182,7 @@ func (m *EnrollmentManager) CreateTicket(t *CreateEnrollmentTicket, opts ...Requ
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return EnrollmentTicket{}, newError(res.Body)
+		return EnrollmentTicket{}, newError(res)
 	}
 
 	var out EnrollmentTicket
@@ -212,8 +238,15 @@ func (m *MultiFactorManager) Policy(opts ...RequestOption) (p *MultiFactorPolici
 // UpdatePolicy updates MFA policies.
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Guardian/put_policies
-// Expects an array of either ["all-applications"] or ["confidence-score"].
+// Expects an array of either ["all-applications"] or ["confidence-score"], or
+// an empty array to never require MultiFactor authentication. Unknown policies
+// are rejected without contacting the API.
 func (m *MultiFactorManager) UpdatePolicy(p *MultiFactorPolicies, opts ...RequestOption) error {
+	if p != nil {
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
 	return m.Request("PUT", m.URI("guardian", "policies"), p, opts...)
 }
 
@@ -255,3 +255,21 @@ func TestGuardian(t *testing.T) {
 		})
 	})
 }
+
+func TestMultiFactorPoliciesValidate(t *testing.T) {
+	for _, test := range []struct {
+		policies MultiFactorPolicies
+		valid    bool
+	}{
+		{MultiFactorPolicies{}, true},
+		{MultiFactorPolicies{MultiFactorPolicyAllApplications}, true},
+		{MultiFactorPolicies{MultiFactorPolicyConfidenceScore}, true},
+		{MultiFactorPolicies{"never"}, false},
+		{MultiFactorPolicies{MultiFactorPolicyAllApplications, "sometimes"}, false},
+	} {
+		err := test.policies.validate()
+		if (err == nil) != test.valid {
+			t.Errorf("unexpected validation result for %v: %v", test.policies, err)
+		}
+	}
+}
@@ -116,7 +116,7 @@ func (m *HookManager) Delete(id string, opts ...RequestOption) error {
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Hooks/get_hooks
 func (m *HookManager) List(opts ...RequestOption) (l *HookList, err error) {
-	err = m.Request("GET", m.URI("hooks"), &l, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("hooks"), &l, applyListDefaults(opts)...)
 	return
 }
 
@@ -128,6 +128,11 @@ func (m *HookManager) CreateSecrets(hookID string, s HookSecrets, opts ...Reques
 	return m.Request("POST", m.URI("hooks", hookID, "secrets"), &s, opts...)
 }
 
+// AddSecrets is an alias for CreateSecrets.
+func (m *HookManager) AddSecrets(hookID string, s HookSecrets, opts ...RequestOption) error {
+	return m.CreateSecrets(hookID, s, opts...)
+}
+
 // UpdateSecrets updates one or more existing secrets for an existing hook.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Hooks/patch_secrets
@@ -160,7 +165,8 @@ func (m *HookManager) ReplaceSecrets(hookID string, s HookSecrets, opts ...Reque
 // Secrets retrieves a hook's secrets by the ID of the hook.
 //
 // Note: For security, hook secret values cannot be retrieved outside rule
-// execution (they all appear as "_VALUE_NOT_SHOWN_").
+// execution (they all appear as "_VALUE_NOT_SHOWN_"). Only the keys of the
+// returned secrets are meaningful, see HookSecrets.Keys.
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Hooks/get_secrets
 func (m *HookManager) Secrets(hookID string, opts ...RequestOption) (s HookSecrets, err error) {
@@ -178,13 +184,13 @@ func (m *HookManager) RemoveSecrets(hookID string, keys []string, opts ...Reques
 
 // RemoveAllSecrets removes all secrets associated with a given hook.
 func (m *HookManager) RemoveAllSecrets(hookID string, opts ...RequestOption) (err error) {
-	s, err := m.Secrets(hookID)
+	s, err := m.Secrets(hookID, opts...)
 	if err != nil {
 		return err
 	}
 	keys := s.Keys()
 	if len(keys) > 0 {
-		err = m.RemoveSecrets(hookID, keys)
+		err = m.RemoveSecrets(hookID, keys, opts...)
 	}
 	return err
 }
@@ -3,8 +3,8 @@ package management
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"mime/multipart"
-	"net/http"
 	"net/textproto"
 	"strconv"
 	"time"
@@ -61,6 +61,45 @@ type Job struct {
 	SendCompletionEmail *bool `json:"send_completion_email,omitempty"`
 }
 
+const (
+	// JobStatusPending constant.
+	JobStatusPending = "pending"
+	// JobStatusProcessing constant.
+	JobStatusProcessing = "processing"
+	// JobStatusCompleted constant.
+	JobStatusCompleted = "completed"
+	// JobStatusFailed constant.
+	JobStatusFailed = "failed"
+)
+
+const (
+	// defaultJobPollInterval is the time waited before the first poll of a
+	// job when no valid poll interval is provided.
+	defaultJobPollInterval = time.Second
+
+	// maxJobPollInterval is the maximum time waited between polls of a job.
+	maxJobPollInterval = 30 * time.Second
+)
+
+// JobError is used to report the errors of a job, such as the users which
+// could not be imported by an import job.
+type JobError struct {
+	// The user which could not be processed.
+	User map[string]interface{} `json:"user,omitempty"`
+	// The errors encountered when processing the user.
+	Errors []*JobUserError `json:"errors,omitempty"`
+}
+
+// JobUserError is an error encountered when processing a single user.
+type JobUserError struct {
+	// The error code.
+	Code *string `json:"code,omitempty"`
+	// A human readable description of the error.
+	Message *string `json:"message,omitempty"`
+	// The path to the field which caused the error.
+	Path *string `json:"path,omitempty"`
+}
+
 // JobManager manages Auth0 Job resources.
 type JobManager struct {
 	*Management
@@ -80,7 +119,16 @@ func (m *JobManager) VerifyEmail(j *Job, opts ...RequestOption) error {
 //
 // See: https://auth0.com/docs/api/management/v2#!/Jobs/get_jobs_by_id
 func (m *JobManager) Read(id string, opts ...RequestOption) (j *Job, err error) {
-	err = m.Request("GET", m.URI("jobs", id), &j)
+	err = m.Request("GET", m.URI("jobs", id), &j, opts...)
+	return
+}
+
+// Errors retrieves the errors of a failed job, or of an import job which
+// failed to import some of the users.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Jobs/get_errors
+func (m *JobManager) Errors(id string, opts ...RequestOption) (e []*JobError, err error) {
+	err = m.Request("GET", m.URI("jobs", id, "errors"), &e, opts...)
 	return
 }
 
@@ -126,29 +174,53 @@ func (m *JobManager) ImportUsers(j *Job, opts ...RequestOption) error {
 	}
 	mp.Close()
 
-	req, err := http.NewRequest("POST", m.URI("jobs", "users-imports"), &payload)
-	if err != nil {
-		return err
-	}
-	req.Header.Add("Content-Type", mp.FormDataContentType())
+	return m.request("POST", m.URI("jobs", "users-imports"), &payload, j, append(opts, ContentType(mp.FormDataContentType()))...)
+}
 
-	for _, option := range opts {
-		option.apply(req)
+// ImportUsersAndWait imports users like ImportUsers and waits until the job
+// has finished, polling its status starting at pollInterval and backing off
+// up to 30 seconds between polls. A pollInterval of 0 or less defaults to 1
+// second. Once finished, j holds the final state of the job and the errors of
+// the users which could not be imported are returned.
+//
+// An error is returned if the job failed, together with the job errors if
+// they could be retrieved. Waiting can be cancelled using the Context request
+// option.
+func (m *JobManager) ImportUsersAndWait(j *Job, pollInterval time.Duration, opts ...RequestOption) ([]*JobError, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultJobPollInterval
 	}
 
-	res, err := m.Do(req)
-	if err != nil {
-		return err
+	if err := m.ImportUsers(j, opts...); err != nil {
+		return nil, err
 	}
 
-	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return newError(res.Body)
+	ctx := m.requestContext(opts)
+
+	for j.GetStatus() == JobStatusPending || j.GetStatus() == JobStatusProcessing {
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if pollInterval *= 2; pollInterval > maxJobPollInterval {
+			pollInterval = maxJobPollInterval
+		}
+
+		r, err := m.Read(j.GetID(), opts...)
+		if err != nil {
+			return nil, err
+		}
+		*j = *r
 	}
 
-	if res.StatusCode != http.StatusNoContent {
-		defer res.Body.Close()
-		return json.NewDecoder(res.Body).Decode(j)
+	if j.GetStatus() == JobStatusFailed {
+		// The errors are retrieved on a best effort basis, so that failing
+		// to retrieve them doesn't hide the failure of the job itself.
+		jobErrors, _ := m.Errors(j.GetID(), opts...)
+		return jobErrors, fmt.Errorf("job %s failed", j.GetID())
 	}
 
-	return nil
+	return m.Errors(j.GetID(), opts...)
 }
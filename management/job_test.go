@@ -1,9 +1,17 @@
 package management
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestJob(t *testing.T) {
@@ -90,3 +98,150 @@ func TestJob(t *testing.T) {
 		t.Log(job)
 	})
 }
+
+func TestJobManagerImportUsersAndWait(t *testing.T) {
+	reads := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/jobs/users-imports":
+			w.Write([]byte(`{"id":"job_123","status":"pending","type":"users_import"}`))
+		case "/api/v2/jobs/job_123":
+			reads++
+			if reads < 2 {
+				w.Write([]byte(`{"id":"job_123","status":"processing","type":"users_import"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"job_123","status":"completed","type":"users_import"}`))
+		case "/api/v2/jobs/job_123/errors":
+			w.Write([]byte(`[{"user":{"email":"alex@example.com"},"errors":[{"code":"INVALID_FORMAT","message":"Error in email property","path":"email"}]}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := &Job{
+		ConnectionID: auth0.String("con_123"),
+		Users: []map[string]interface{}{
+			{"email": "alex@example.com"},
+		},
+	}
+
+	jobErrors, err := m.Job.ImportUsersAndWait(job, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, job.GetStatus(), JobStatusCompleted)
+	expect.Expect(t, reads, 2)
+	expect.Expect(t, len(jobErrors), 1)
+	expect.Expect(t, jobErrors[0].Errors[0].GetCode(), "INVALID_FORMAT")
+	expect.Expect(t, jobErrors[0].Errors[0].GetPath(), "email")
+}
+
+func TestJobManagerImportUsersAndWaitFailed(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/jobs/users-imports":
+			w.Write([]byte(`{"id":"job_123","status":"pending","type":"users_import"}`))
+		case "/api/v2/jobs/job_123":
+			w.Write([]byte(`{"id":"job_123","status":"failed","type":"users_import"}`))
+		case "/api/v2/jobs/job_123/errors":
+			w.Write([]byte(`{"id":"job_123","status":"failed","type":"users_import"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := &Job{ConnectionID: auth0.String("con_123")}
+
+	jobErrors, err := m.Job.ImportUsersAndWait(job, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "job job_123 failed") {
+		t.Errorf("expected the job to fail, got %v", err)
+	}
+
+	expect.Expect(t, job.GetStatus(), JobStatusFailed)
+	expect.Expect(t, len(jobErrors), 0)
+}
+
+func TestJobManagerImportUsersAndWaitCancel(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"job_123","status":"processing","type":"users_import"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	job := &Job{ConnectionID: auth0.String("con_123")}
+
+	_, err = m.Job.ImportUsersAndWait(job, time.Millisecond, Context(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestJobManagerImportUsers(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "POST")
+		expect.Expect(t, r.URL.Path, "/api/v2/jobs/users-imports")
+		expect.Expect(t, r.Header.Get("X-Default"), "default")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, r.FormValue("connection_id"), "con_123")
+		f, _, err := r.FormFile("users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(f)
+		expect.Expect(t, string(b), `[{"email":"alex@example.com"}]`)
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"job_123","status":"pending","type":"users_import"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithDefaultOptions(Header("X-Default", "default")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := &Job{
+		ConnectionID: auth0.String("con_123"),
+		Users:        []map[string]interface{}{{"email": "alex@example.com"}},
+	}
+	if err := m.Job.ImportUsers(job); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, job.GetID(), "job_123")
+	expect.Expect(t, job.GetStatus(), "pending")
+
+	m, err = New(s.URL, WithInsecure(), WithMaxBodyBytes(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Job.ImportUsers(job); err == nil {
+		t.Error("expected the payload size limit to apply")
+	}
+}
@@ -1,6 +1,9 @@
 package management
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -133,7 +136,135 @@ func (m *LogManager) List(opts ...RequestOption) (l []*Log, err error) {
 	return
 }
 
+// Count returns the amount of log entries matching the given options, e.g.
+// Query, without retrieving any of them. Note that the Auth0 Management API
+// only counts the log entries which can be listed using page based
+// pagination.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Logs/get_logs
+func (m *LogManager) Count(opts ...RequestOption) (int, error) {
+	return m.count(m.URI("logs"), opts)
+}
+
+// ListStream retrieves log entries like List, calling fn for each log entry
+// as it is decoded from the response payload instead of holding the entire
+// response in memory.
+//
+// Returning an error from fn stops the retrieval, and the error is returned
+// by ListStream.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Logs/get_logs
+func (m *LogManager) ListStream(fn func(l *Log) error, opts ...RequestOption) error {
+	_, err := m.stream(m.URI("logs"), "", func(dec *json.Decoder) error {
+		var l *Log
+		if err := dec.Decode(&l); err != nil {
+			return fmt.Errorf("decoding response payload failed: %w", err)
+		}
+		return fn(l)
+	}, opts...)
+	return err
+}
+
 // Search is an alias for List.
 func (m *LogManager) Search(opts ...RequestOption) ([]*Log, error) {
 	return m.List(opts...)
 }
+
+// ListCheckpoint lists log entries using checkpoint pagination, retrieving at
+// most take log entries starting from the log entry identified by from. If
+// from is empty the most recent log entries are retrieved.
+//
+// The returned next value is the id of the last log entry retrieved, which
+// can be used as from in the subsequent call. It is empty when no log
+// entries were retrieved.
+//
+// See: https://auth0.com/docs/logs/retrieve-log-events-using-mgmt-api#get-logs-by-checkpoint
+func (m *LogManager) ListCheckpoint(from string, take int, opts ...RequestOption) (l []*Log, next string, err error) {
+	if from != "" {
+		opts = append(opts, From(from))
+	}
+	opts = append(opts, Take(take))
+
+	l, err = m.List(opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return l, LastLogID(l), nil
+}
+
+// ListAll returns an iterator over log entries using checkpoint pagination,
+// transparently requesting additional entries as needed. The request options
+// are applied to every request.
+//
+// The iteration starts from the log entry set using the From option, or from
+// the most recent log entries if it is not set, and ends once a request
+// retrieves fewer entries than requested using Take, which defaults to 100.
+//
+// See: https://auth0.com/docs/logs/retrieve-log-events-using-mgmt-api#get-logs-by-checkpoint
+func (m *LogManager) ListAll(opts ...RequestOption) *LogIterator {
+	return &LogIterator{m: m, opts: append([]RequestOption{Take(100)}, opts...)}
+}
+
+// LogIterator iterates over log entries using checkpoint pagination.
+type LogIterator struct {
+	m    *LogManager
+	opts []RequestOption
+	next string
+	logs []*Log
+	done bool
+	err  error
+}
+
+// Next returns the next log entry. Once all log entries have been returned it
+// returns ErrIteratorDone, or the error of the failed request if any.
+func (it *LogIterator) Next() (*Log, error) {
+	for len(it.logs) == 0 {
+		if it.err != nil {
+			return nil, it.err
+		}
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+
+		opts := it.opts
+		if it.next != "" {
+			opts = append(opts[:len(opts):len(opts)], From(it.next))
+		}
+		r, _ := it.m.NewRequest("GET", it.m.URI("logs"), nil, opts...)
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+
+		l, err := it.m.List(opts...)
+		if err != nil {
+			it.err = err
+			return nil, err
+		}
+		it.logs = l
+		it.done = len(l) < take || len(l) == 0
+		if len(l) > 0 {
+			it.next = LastLogID(l)
+		}
+	}
+
+	l := it.logs[0]
+	it.logs = it.logs[1:]
+
+	return l, nil
+}
+
+// Checkpoint returns the id of the last log entry retrieved so far, which can
+// be passed to From to resume the iteration later on. It is empty until the
+// first log entries are retrieved.
+func (it *LogIterator) Checkpoint() string {
+	return it.next
+}
+
+// LastLogID returns the log id of the last entry in l, or an empty string if
+// l is empty. It is intended to be used as the checkpoint for subsequent
+// requests using From.
+func LastLogID(l []*Log) string {
+	if len(l) == 0 {
+		return ""
+	}
+	return l[len(l)-1].GetLogID()
+}
@@ -1,6 +1,48 @@
 package management
 
-import "encoding/json"
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/auth0/go-auth0"
+)
+
+// LogStreamType is the type of a log stream, as returned by
+// LogStream.TypeEnum. It can be compared against the LogStreamTypeEnum
+// constants, which makes switches over it checkable by exhaustive switch
+// linters. The LogStreamType constants are left untyped so they can still be
+// used as plain strings, e.g. with auth0.String.
+type LogStreamType string
+
+const (
+	// LogStreamTypeEnumAmazonEventBridge constant.
+	LogStreamTypeEnumAmazonEventBridge LogStreamType = LogStreamTypeAmazonEventBridge
+	// LogStreamTypeEnumAzureEventGrid constant.
+	LogStreamTypeEnumAzureEventGrid LogStreamType = LogStreamTypeAzureEventGrid
+	// LogStreamTypeEnumHTTP constant.
+	LogStreamTypeEnumHTTP LogStreamType = LogStreamTypeHTTP
+	// LogStreamTypeEnumDatadog constant.
+	LogStreamTypeEnumDatadog LogStreamType = LogStreamTypeDatadog
+	// LogStreamTypeEnumSplunk constant.
+	LogStreamTypeEnumSplunk LogStreamType = LogStreamTypeSplunk
+	// LogStreamTypeEnumSumo constant.
+	LogStreamTypeEnumSumo LogStreamType = LogStreamTypeSumo
+	// LogStreamTypeEnumMixpanel constant.
+	LogStreamTypeEnumMixpanel LogStreamType = LogStreamTypeMixpanel
+	// LogStreamTypeEnumSegment constant.
+	LogStreamTypeEnumSegment LogStreamType = LogStreamTypeSegment
+)
 
 const (
 	// LogStreamTypeAmazonEventBridge constant.
@@ -15,8 +57,136 @@ const (
 	LogStreamTypeSplunk = "splunk"
 	// LogStreamTypeSumo constant.
 	LogStreamTypeSumo = "sumo"
+	// LogStreamTypeMixpanel constant.
+	LogStreamTypeMixpanel = "mixpanel"
+	// LogStreamTypeSegment constant.
+	LogStreamTypeSegment = "segment"
+)
+
+const (
+	// LogStreamStatusActive constant.
+	LogStreamStatusActive = "active"
+	// LogStreamStatusPaused constant.
+	LogStreamStatusPaused = "paused"
+	// LogStreamStatusSuspended constant. This status is set by Auth0 when a
+	// log stream repeatedly fails to deliver logs, and cannot be set by callers.
+	LogStreamStatusSuspended = "suspended"
+)
+
+const (
+	// LogStreamHTTPContentFormatJSONArray constant.
+	LogStreamHTTPContentFormatJSONArray = "JSONARRAY"
+	// LogStreamHTTPContentFormatJSONLines constant.
+	LogStreamHTTPContentFormatJSONLines = "JSONLINES"
+	// LogStreamHTTPContentFormatJSONObject constant.
+	LogStreamHTTPContentFormatJSONObject = "JSONOBJECT"
+)
+
+const (
+	// DatadogRegionUS1 constant. The US1 site is identified as "us" by the
+	// Auth0 Management API.
+	DatadogRegionUS1 = "us"
+	// DatadogRegionUS3 constant.
+	DatadogRegionUS3 = "us3"
+	// DatadogRegionUS5 constant.
+	DatadogRegionUS5 = "us5"
+	// DatadogRegionEU constant.
+	DatadogRegionEU = "eu"
+	// DatadogRegionUS1FED constant.
+	DatadogRegionUS1FED = "us1-fed"
+	// DatadogRegionAP1 constant.
+	DatadogRegionAP1 = "ap1"
+)
+
+var datadogRegions = []string{
+	DatadogRegionUS1,
+	DatadogRegionUS3,
+	DatadogRegionUS5,
+	DatadogRegionEU,
+	DatadogRegionUS1FED,
+	DatadogRegionAP1,
+}
+
+const (
+	// LogStreamFilterTypeCategory constant.
+	LogStreamFilterTypeCategory = "category"
+)
+
+const (
+	// LogStreamFilterCategoryAuthAncillaryFail constant.
+	LogStreamFilterCategoryAuthAncillaryFail = "auth.ancillary.fail"
+	// LogStreamFilterCategoryAuthAncillarySuccess constant.
+	LogStreamFilterCategoryAuthAncillarySuccess = "auth.ancillary.success"
+	// LogStreamFilterCategoryAuthLoginFail constant.
+	LogStreamFilterCategoryAuthLoginFail = "auth.login.fail"
+	// LogStreamFilterCategoryAuthLoginNotification constant.
+	LogStreamFilterCategoryAuthLoginNotification = "auth.login.notification"
+	// LogStreamFilterCategoryAuthLoginSuccess constant.
+	LogStreamFilterCategoryAuthLoginSuccess = "auth.login.success"
+	// LogStreamFilterCategoryAuthLogoutFail constant.
+	LogStreamFilterCategoryAuthLogoutFail = "auth.logout.fail"
+	// LogStreamFilterCategoryAuthLogoutSuccess constant.
+	LogStreamFilterCategoryAuthLogoutSuccess = "auth.logout.success"
+	// LogStreamFilterCategoryAuthSignupFail constant.
+	LogStreamFilterCategoryAuthSignupFail = "auth.signup.fail"
+	// LogStreamFilterCategoryAuthSignupSuccess constant.
+	LogStreamFilterCategoryAuthSignupSuccess = "auth.signup.success"
+	// LogStreamFilterCategoryAuthSilentAuthFail constant.
+	LogStreamFilterCategoryAuthSilentAuthFail = "auth.silent_auth.fail"
+	// LogStreamFilterCategoryAuthSilentAuthSuccess constant.
+	LogStreamFilterCategoryAuthSilentAuthSuccess = "auth.silent_auth.success"
+	// LogStreamFilterCategoryAuthTokenExchangeFail constant.
+	LogStreamFilterCategoryAuthTokenExchangeFail = "auth.token_exchange.fail"
+	// LogStreamFilterCategoryAuthTokenExchangeSuccess constant.
+	LogStreamFilterCategoryAuthTokenExchangeSuccess = "auth.token_exchange.success"
+	// LogStreamFilterCategoryManagementFail constant.
+	LogStreamFilterCategoryManagementFail = "management.fail"
+	// LogStreamFilterCategoryManagementSuccess constant.
+	LogStreamFilterCategoryManagementSuccess = "management.success"
+	// LogStreamFilterCategorySystemNotification constant.
+	LogStreamFilterCategorySystemNotification = "system.notification"
+	// LogStreamFilterCategoryUserFail constant.
+	LogStreamFilterCategoryUserFail = "user.fail"
+	// LogStreamFilterCategoryUserNotification constant.
+	LogStreamFilterCategoryUserNotification = "user.notification"
+	// LogStreamFilterCategoryUserSuccess constant.
+	LogStreamFilterCategoryUserSuccess = "user.success"
+	// LogStreamFilterCategoryOther constant.
+	LogStreamFilterCategoryOther = "other"
+)
+
+const (
+	// LogStreamPIIFieldFirstName constant.
+	LogStreamPIIFieldFirstName = "first_name"
+	// LogStreamPIIFieldLastName constant.
+	LogStreamPIIFieldLastName = "last_name"
+	// LogStreamPIIFieldUsername constant.
+	LogStreamPIIFieldUsername = "username"
+	// LogStreamPIIFieldEmail constant.
+	LogStreamPIIFieldEmail = "email"
+	// LogStreamPIIFieldPhone constant.
+	LogStreamPIIFieldPhone = "phone"
+	// LogStreamPIIFieldAddress constant.
+	LogStreamPIIFieldAddress = "address"
+
+	// LogStreamPIIMethodMask constant.
+	LogStreamPIIMethodMask = "mask"
+	// LogStreamPIIMethodHash constant.
+	LogStreamPIIMethodHash = "hash"
+
+	// LogStreamPIIAlgorithmXXHash constant.
+	LogStreamPIIAlgorithmXXHash = "xxhash"
 )
 
+var logStreamPIIFields = []string{
+	LogStreamPIIFieldFirstName,
+	LogStreamPIIFieldLastName,
+	LogStreamPIIFieldUsername,
+	LogStreamPIIFieldEmail,
+	LogStreamPIIFieldPhone,
+	LogStreamPIIFieldAddress,
+}
+
 // LogStream is used to export tenant log
 // events to a log event analysis service.
 //
@@ -30,16 +200,99 @@ type LogStream struct {
 	Name *string `json:"name,omitempty"`
 
 	// The type of the log-stream. Can be one of "http", "eventbridge",
-	// "eventgrid", "datadog" or "splunk".
+	// "eventgrid", "datadog", "splunk", "sumo", "mixpanel" or "segment".
 	Type *string `json:"type,omitempty"`
 
 	// The status of the log-stream. Can be one of "active", "paused", or "suspended".
+	//
+	// The "suspended" status is set by Auth0 and is read-only.
 	Status *string `json:"status,omitempty"`
 
+	// Only logs events matching these filters will be delivered by the stream.
+	// If omitted or empty, all events will be delivered.
+	Filters []*LogStreamFilter `json:"filters,omitempty"`
+
+	// PIIConfig configures the redaction of personally identifiable
+	// information from the log events delivered by the stream.
+	PIIConfig *LogStreamPIIConfig `json:"pii_config,omitempty"`
+
 	// Sink for validation.
 	Sink interface{} `json:"-"`
 }
 
+// LogStreamFilter restricts the log events delivered by a log stream.
+type LogStreamFilter struct {
+	// The type of the filter. Can only be "category", see
+	// LogStreamFilterTypeCategory.
+	Type *string `json:"type,omitempty"`
+
+	// The name of the filter, e.g. "auth.login.fail". See the
+	// LogStreamFilterCategory constants.
+	Name *string `json:"name,omitempty"`
+}
+
+// LogStreamPIIConfig configures the redaction of personally identifiable
+// information from the log events delivered by a log stream. The redaction is
+// performed by Auth0 before delivery, it is part of the log stream rather
+// than of its sink.
+type LogStreamPIIConfig struct {
+	// The fields to redact. See the LogStreamPIIField constants.
+	LogFields []string `json:"log_fields,omitempty"`
+
+	// The redaction method, either "mask" or "hash". See the
+	// LogStreamPIIMethod constants.
+	Method *string `json:"method,omitempty"`
+
+	// The hashing algorithm, only used with the "hash" method. Can only be
+	// "xxhash".
+	Algorithm *string `json:"algorithm,omitempty"`
+}
+
+// Validate checks that the fields and method of the PII configuration are
+// known, and that an algorithm is only set when hashing, as masking and
+// hashing are mutually exclusive.
+func (c *LogStreamPIIConfig) Validate() error {
+	if len(c.LogFields) == 0 {
+		return errors.New("the PII configuration must list at least one log field")
+	}
+	for _, f := range c.LogFields {
+		valid := false
+		for _, known := range logStreamPIIFields {
+			valid = valid || f == known
+		}
+		if !valid {
+			return fmt.Errorf("invalid PII log field %q, must be one of %q", f, logStreamPIIFields)
+		}
+	}
+
+	switch c.GetMethod() {
+	case LogStreamPIIMethodMask:
+		if c.Algorithm != nil {
+			return fmt.Errorf("the PII algorithm can only be set with the %q method", LogStreamPIIMethodHash)
+		}
+	case LogStreamPIIMethodHash:
+		if c.Algorithm != nil && *c.Algorithm != LogStreamPIIAlgorithmXXHash {
+			return fmt.Errorf("invalid PII algorithm %q, must be %q", *c.Algorithm, LogStreamPIIAlgorithmXXHash)
+		}
+	default:
+		return fmt.Errorf("invalid PII method %q, must be %q or %q", c.GetMethod(), LogStreamPIIMethodMask, LogStreamPIIMethodHash)
+	}
+	return nil
+}
+
+// LogStreamCategoryFilters returns filters of type "category" for each of the
+// given category names, e.g. LogStreamFilterCategoryAuthLoginFail.
+func LogStreamCategoryFilters(names ...string) []*LogStreamFilter {
+	filters := make([]*LogStreamFilter, len(names))
+	for i, name := range names {
+		filters[i] = &LogStreamFilter{
+			Type: auth0.String(LogStreamFilterTypeCategory),
+			Name: auth0.String(name),
+		}
+	}
+	return filters
+}
+
 // MarshalJSON is a custom serializer for the LogStream type.
 func (ls *LogStream) MarshalJSON() ([]byte, error) {
 	type logStream LogStream
@@ -76,25 +329,10 @@ func (ls *LogStream) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	if ls.Type != nil {
-		var v interface{}
-
-		switch *ls.Type {
-		case LogStreamTypeAmazonEventBridge:
-			v = &LogStreamSinkAmazonEventBridge{}
-		case LogStreamTypeAzureEventGrid:
-			v = &LogStreamSinkAzureEventGrid{}
-		case LogStreamTypeHTTP:
-			v = &LogStreamSinkHTTP{}
-		case LogStreamTypeDatadog:
-			v = &LogStreamSinkDatadog{}
-		case LogStreamTypeSplunk:
-			v = &LogStreamSinkSplunk{}
-		case LogStreamTypeSumo:
-			v = &LogStreamSinkSumo{}
-		default:
-			v = make(map[string]interface{})
-		}
+	// The sink is missing from the response payload if it was excluded
+	// using IncludeFields or ExcludeFields.
+	if ls.Type != nil && len(w.RawSink) > 0 {
+		v := newLogStreamSink(*ls.Type)
 
 		err = json.Unmarshal(w.RawSink, &v)
 		if err != nil {
@@ -107,6 +345,399 @@ func (ls *LogStream) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// newLogStreamSink returns an empty sink of the concrete type associated with
+// the log stream type t. Unknown types are represented by a generic map.
+func newLogStreamSink(t string) interface{} {
+	switch LogStreamType(t) {
+	case LogStreamTypeEnumAmazonEventBridge:
+		return &LogStreamSinkAmazonEventBridge{}
+	case LogStreamTypeEnumAzureEventGrid:
+		return &LogStreamSinkAzureEventGrid{}
+	case LogStreamTypeEnumHTTP:
+		return &LogStreamSinkHTTP{}
+	case LogStreamTypeEnumDatadog:
+		return &LogStreamSinkDatadog{}
+	case LogStreamTypeEnumSplunk:
+		return &LogStreamSinkSplunk{}
+	case LogStreamTypeEnumSumo:
+		return &LogStreamSinkSumo{}
+	case LogStreamTypeEnumMixpanel:
+		return &LogStreamSinkMixpanel{}
+	case LogStreamTypeEnumSegment:
+		return &LogStreamSinkSegment{}
+	default:
+		return make(map[string]interface{})
+	}
+}
+
+// Validate checks that the concrete type of the Sink matches the Type of the
+// log stream. Streams of an unknown type or without a Type or Sink set are
+// not checked.
+//
+// The content format of HTTP sinks, if set, is also checked to be one of the
+// LogStreamHTTPContentFormat constants, and Splunk and Datadog sinks are
+// checked using LogStreamSinkSplunk.Validate and LogStreamSinkDatadog.Validate.
+// The PIIConfig, if set, is checked using LogStreamPIIConfig.Validate.
+func (ls *LogStream) Validate() error {
+	if ls.PIIConfig != nil {
+		if err := ls.PIIConfig.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s, ok := ls.Sink.(*LogStreamSinkSplunk); ok {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s, ok := ls.Sink.(*LogStreamSinkDatadog); ok {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s, ok := ls.Sink.(*LogStreamSinkHTTP); ok && s.ContentFormat != nil {
+		switch f := *s.ContentFormat; f {
+		case LogStreamHTTPContentFormatJSONArray, LogStreamHTTPContentFormatJSONLines, LogStreamHTTPContentFormatJSONObject:
+		default:
+			return fmt.Errorf("invalid HTTP content format %q, must be one of %q, %q or %q", f,
+				LogStreamHTTPContentFormatJSONArray, LogStreamHTTPContentFormatJSONLines, LogStreamHTTPContentFormatJSONObject)
+		}
+	}
+
+	if ls.Type == nil || ls.Sink == nil {
+		return nil
+	}
+
+	expected := reflect.TypeOf(newLogStreamSink(*ls.Type))
+	if expected.Kind() == reflect.Map {
+		return nil
+	}
+
+	if actual := reflect.TypeOf(ls.Sink); actual != expected {
+		return fmt.Errorf("log stream of type %q requires a sink of type %s, got %s", *ls.Type, expected, actual)
+	}
+
+	return nil
+}
+
+// ValidateSink checks that the log stream can be created. In addition to the
+// checks performed by Validate, it checks that the fields required by the
+// sink are set, e.g. the APIKey and Region of a Datadog sink.
+//
+// Streams without a Sink or with an untyped Sink are not checked.
+func (ls *LogStream) ValidateSink() error {
+	if err := ls.Validate(); err != nil {
+		return err
+	}
+
+	var missing []string
+	require := func(field string, set bool) {
+		if !set {
+			missing = append(missing, field)
+		}
+	}
+
+	switch s := ls.Sink.(type) {
+	case *LogStreamSinkAmazonEventBridge:
+		require("awsAccountId", s.AccountID != nil)
+		require("awsRegion", s.Region != nil)
+	case *LogStreamSinkAzureEventGrid:
+		require("azureSubscriptionId", s.SubscriptionID != nil)
+		require("azureResourceGroup", s.ResourceGroup != nil)
+		require("azureRegion", s.Region != nil)
+	case *LogStreamSinkHTTP:
+		require("httpEndpoint", s.Endpoint != nil)
+	case *LogStreamSinkDatadog:
+		require("datadogRegion", s.Region != nil)
+		require("datadogApiKey", s.APIKey != nil)
+	case *LogStreamSinkSplunk:
+		require("splunkDomain", s.Domain != nil)
+		require("splunkToken", s.Token != nil)
+		require("splunkPort", s.Port != nil)
+	case *LogStreamSinkSumo:
+		require("sumoSourceAddress", s.SourceAddress != nil)
+	case *LogStreamSinkMixpanel:
+		require("mixpanelRegion", s.Region != nil)
+		require("mixpanelProjectId", s.ProjectID != nil)
+		require("mixpanelServiceAccountUsername", s.ServiceAccountUsername != nil)
+		require("mixpanelServiceAccountPassword", s.ServiceAccountPassword != nil)
+	case *LogStreamSinkSegment:
+		require("segmentWriteKey", s.WriteKey != nil)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("log stream sink %T is missing required fields: %s", ls.Sink, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Clone returns a deep copy of the log stream, ready to be used with Create.
+// The ID and Status of the log stream are not copied.
+//
+// The Sink is copied by encoding it to JSON and decoding it into a new value
+// of the same type, so its Extra properties and slices such as the
+// CustomHeaders of an HTTP sink are copied as well.
+func (ls *LogStream) Clone() *LogStream {
+	c := &LogStream{}
+	if ls.Name != nil {
+		c.Name = auth0.String(*ls.Name)
+	}
+	if ls.Type != nil {
+		c.Type = auth0.String(*ls.Type)
+	}
+	if ls.Filters != nil {
+		c.Filters = make([]*LogStreamFilter, len(ls.Filters))
+		for i, f := range ls.Filters {
+			c.Filters[i] = &LogStreamFilter{}
+			if f.Type != nil {
+				c.Filters[i].Type = auth0.String(*f.Type)
+			}
+			if f.Name != nil {
+				c.Filters[i].Name = auth0.String(*f.Name)
+			}
+		}
+	}
+	if ls.PIIConfig != nil {
+		c.PIIConfig = &LogStreamPIIConfig{LogFields: append([]string(nil), ls.PIIConfig.LogFields...)}
+		if ls.PIIConfig.Method != nil {
+			c.PIIConfig.Method = auth0.String(*ls.PIIConfig.Method)
+		}
+		if ls.PIIConfig.Algorithm != nil {
+			c.PIIConfig.Algorithm = auth0.String(*ls.PIIConfig.Algorithm)
+		}
+	}
+	if ls.Sink != nil {
+		c.Sink = cloneLogStreamSink(ls.Sink)
+	}
+	return c
+}
+
+// cloneLogStreamSink returns a deep copy of sink. Sinks which cannot be
+// encoded to JSON, which is never the case of the sinks of this package, are
+// returned as is.
+func cloneLogStreamSink(sink interface{}) interface{} {
+	b, err := json.Marshal(sink)
+	if err != nil {
+		return sink
+	}
+	t := reflect.TypeOf(sink)
+	if t.Kind() != reflect.Ptr {
+		v := reflect.New(t)
+		if json.Unmarshal(b, v.Interface()) != nil {
+			return sink
+		}
+		return v.Elem().Interface()
+	}
+	v := reflect.New(t.Elem()).Interface()
+	if json.Unmarshal(b, v) != nil {
+		return sink
+	}
+	return v
+}
+
+// LogStreamPatch returns a log stream holding only the fields of desired which
+// differ from current, to be used as the payload of LogStreamManager.Update so
+// that fields which were not meant to be changed are not sent. Fields of
+// desired which are not set are left untouched. The filters and the PII
+// configuration are replaced as a whole if any of their fields differ.
+//
+// The sink is compared property by property, and the returned sink, of the
+// same type as the sink of desired, only holds the properties which differ. An
+// error is returned if the type of the log stream would change or if the sink
+// of an eventbridge or eventgrid log stream would change, as neither can be
+// updated. If nothing differs, the returned log stream has no field set.
+func LogStreamPatch(current, desired *LogStream) (*LogStream, error) {
+	patch := &LogStream{}
+
+	if desired.Type != nil && desired.GetType() != current.GetType() {
+		return nil, fmt.Errorf("the type of a log stream cannot be changed from %q to %q", current.GetType(), desired.GetType())
+	}
+	if desired.Name != nil && desired.GetName() != current.GetName() {
+		patch.Name = auth0.String(desired.GetName())
+	}
+	if desired.Status != nil && desired.GetStatus() != current.GetStatus() {
+		patch.Status = auth0.String(desired.GetStatus())
+	}
+	if desired.Filters != nil && !reflect.DeepEqual(desired.Filters, current.Filters) {
+		patch.Filters = desired.Filters
+	}
+	if desired.PIIConfig != nil && !reflect.DeepEqual(desired.PIIConfig, current.PIIConfig) {
+		patch.PIIConfig = desired.PIIConfig
+	}
+
+	if desired.Sink != nil {
+		sink, err := logStreamSinkPatch(current.Sink, desired.Sink)
+		if err != nil {
+			return nil, err
+		}
+		if sink != nil {
+			t := current.GetType()
+			switch desired.Sink.(type) {
+			case *LogStreamSinkAmazonEventBridge:
+				t = LogStreamTypeAmazonEventBridge
+			case *LogStreamSinkAzureEventGrid:
+				t = LogStreamTypeAzureEventGrid
+			}
+			if t == LogStreamTypeAmazonEventBridge || t == LogStreamTypeAzureEventGrid {
+				return nil, fmt.Errorf("updating the sink of a log stream of type %q is not permitted", t)
+			}
+			patch.Sink = sink
+		}
+	}
+
+	return patch, nil
+}
+
+// logStreamSinkPatch returns a sink of the same type as desired holding only
+// the properties which differ from current, or nil if none differ.
+func logStreamSinkPatch(current, desired interface{}) (interface{}, error) {
+	properties := func(sink interface{}) (map[string]interface{}, error) {
+		m := make(map[string]interface{})
+		if sink == nil {
+			return m, nil
+		}
+		b, err := json.Marshal(sink)
+		if err != nil {
+			return nil, err
+		}
+		return m, json.Unmarshal(b, &m)
+	}
+
+	c, err := properties(current)
+	if err != nil {
+		return nil, err
+	}
+	d, err := properties(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]interface{})
+	for k, v := range d {
+		if !reflect.DeepEqual(v, c[k]) {
+			diff[k] = v
+		}
+	}
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(desired)
+	if t.Kind() != reflect.Ptr {
+		return diff, nil
+	}
+	b, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+	sink := reflect.New(t.Elem()).Interface()
+	if err := json.Unmarshal(b, sink); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// TypeEnum returns the Type of the log stream as a LogStreamType, to be
+// compared against the LogStreamTypeEnum constants, or an empty LogStreamType
+// if it is not set.
+func (ls *LogStream) TypeEnum() LogStreamType {
+	return LogStreamType(ls.GetType())
+}
+
+// IsSuspended reports whether the log stream was suspended by Auth0 after
+// repeatedly failing to deliver logs.
+func (ls *LogStream) IsSuspended() bool {
+	return ls.GetStatus() == LogStreamStatusSuspended
+}
+
+// AmazonEventBridgeSink returns the Sink as an Amazon EventBridge sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) AmazonEventBridgeSink() (*LogStreamSinkAmazonEventBridge, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkAmazonEventBridge)
+	return s, ok
+}
+
+// AzureEventGridSink returns the Sink as an Azure Event Grid sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) AzureEventGridSink() (*LogStreamSinkAzureEventGrid, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkAzureEventGrid)
+	return s, ok
+}
+
+// HTTPSink returns the Sink as an HTTP sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) HTTPSink() (*LogStreamSinkHTTP, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkHTTP)
+	return s, ok
+}
+
+// DatadogSink returns the Sink as a Datadog sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) DatadogSink() (*LogStreamSinkDatadog, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkDatadog)
+	return s, ok
+}
+
+// SplunkSink returns the Sink as a Splunk sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) SplunkSink() (*LogStreamSinkSplunk, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkSplunk)
+	return s, ok
+}
+
+// SumoSink returns the Sink as a Sumo Logic sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) SumoSink() (*LogStreamSinkSumo, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkSumo)
+	return s, ok
+}
+
+// MixpanelSink returns the Sink as a Mixpanel sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) MixpanelSink() (*LogStreamSinkMixpanel, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkMixpanel)
+	return s, ok
+}
+
+// SegmentSink returns the Sink as a Segment sink. The boolean is false if the
+// Sink is of a different type.
+func (ls *LogStream) SegmentSink() (*LogStreamSinkSegment, bool) {
+	s, ok := ls.Sink.(*LogStreamSinkSegment)
+	return s, ok
+}
+
+// validateUpdate checks that the log stream can be used as the payload of an
+// update operation. In addition to the checks performed by Validate, it
+// rejects the read-only "suspended" status and any attempt to modify the sink
+// of eventbridge and eventgrid log streams, as neither is permitted by the API.
+func (ls *LogStream) validateUpdate() error {
+	if ls.IsSuspended() {
+		return fmt.Errorf("the %q log stream status is set by Auth0 and cannot be updated, use %q or %q instead",
+			LogStreamStatusSuspended, LogStreamStatusActive, LogStreamStatusPaused)
+	}
+
+	if ls.Sink == nil {
+		return nil
+	}
+
+	t := ls.GetType()
+	switch ls.Sink.(type) {
+	case *LogStreamSinkAmazonEventBridge:
+		t = LogStreamTypeAmazonEventBridge
+	case *LogStreamSinkAzureEventGrid:
+		t = LogStreamTypeAzureEventGrid
+	}
+
+	if t == LogStreamTypeAmazonEventBridge || t == LogStreamTypeAzureEventGrid {
+		return fmt.Errorf("updating the sink of a log stream of type %q is not permitted", t)
+	}
+
+	return ls.Validate()
+}
+
 // LogStreamSinkAmazonEventBridge is used to export logs to Amazon EventBridge.
 type LogStreamSinkAmazonEventBridge struct {
 	// AWS Account Id
@@ -115,6 +746,23 @@ type LogStreamSinkAmazonEventBridge struct {
 	Region *string `json:"awsRegion,omitempty"`
 	// AWS Partner Event Source
 	PartnerEventSource *string `json:"awsPartnerEventSource,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkAmazonEventBridge type.
+func (s *LogStreamSinkAmazonEventBridge) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkAmazonEventBridge
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkAmazonEventBridge type.
+func (s *LogStreamSinkAmazonEventBridge) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkAmazonEventBridge
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 // LogStreamSinkAzureEventGrid is used to export logs to Azure Event Grid.
@@ -127,6 +775,23 @@ type LogStreamSinkAzureEventGrid struct {
 	Region *string `json:"azureRegion,omitempty"`
 	// Azure Partner Topic
 	PartnerTopic *string `json:"azurePartnerTopic,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkAzureEventGrid type.
+func (s *LogStreamSinkAzureEventGrid) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkAzureEventGrid
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkAzureEventGrid type.
+func (s *LogStreamSinkAzureEventGrid) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkAzureEventGrid
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 // LogStreamSinkHTTP is used to export logs to Custom Webhooks.
@@ -141,6 +806,37 @@ type LogStreamSinkHTTP struct {
 	Authorization *string `json:"httpAuthorization,omitempty"`
 	// Custom HTTP headers
 	CustomHeaders []*LogStreamSinkHTTPCustomHeaders `json:"httpCustomHeaders,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+
+	tlsConfig *tls.Config
+}
+
+// WithTLSConfig configures the TLS settings used by TestEndpoint when no
+// client is passed to it, e.g. to trust the private CA of an endpoint during
+// local validation. It returns s, so that calls can be chained.
+//
+// The TLS settings are only used locally and are never sent to Auth0. Auth0
+// delivers logs using its own trust store, so in production the endpoint
+// must present a certificate issued by a publicly trusted CA.
+func (s *LogStreamSinkHTTP) WithTLSConfig(config *tls.Config) *LogStreamSinkHTTP {
+	s.tlsConfig = config
+	return s
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkHTTP type.
+func (s *LogStreamSinkHTTP) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkHTTP
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkHTTP type.
+func (s *LogStreamSinkHTTP) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkHTTP
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 type LogStreamSinkHTTPCustomHeaders struct {
@@ -150,12 +846,159 @@ type LogStreamSinkHTTPCustomHeaders struct {
 	Value *string `json:"value,omitempty"`
 }
 
+// TestEndpoint checks that the endpoint of the sink is reachable by sending it
+// a sample log event, using the given client. If client is nil,
+// http.DefaultClient is used, or a client using the TLS settings configured
+// with WithTLSConfig if any.
+// The event is formatted according to the ContentFormat of the sink and sent
+// with its ContentType, Authorization and CustomHeaders.
+//
+// An error is returned if the endpoint doesn't respond with a 2xx status
+// code. This is a client side check which is independent of Auth0. It does not
+// guarantee that Auth0 is able to deliver logs to the endpoint, e.g. when the
+// endpoint is only reachable from the caller's network.
+func (s *LogStreamSinkHTTP) TestEndpoint(ctx context.Context, client *http.Client) error {
+	if s.Endpoint == nil {
+		return errors.New("log stream sink has no endpoint")
+	}
+	if client == nil {
+		client = http.DefaultClient
+		if s.tlsConfig != nil {
+			t := http.DefaultTransport.(*http.Transport).Clone()
+			t.TLSClientConfig = s.tlsConfig
+			client = &http.Client{Transport: t}
+		}
+	}
+
+	event := []byte(`{"log_id":"test","data":{"type":"test","description":"Test event sent by go-auth0"}}`)
+	var body []byte
+	switch s.GetContentFormat() {
+	case LogStreamHTTPContentFormatJSONArray:
+		body = append(append([]byte("["), event...), ']')
+	case LogStreamHTTPContentFormatJSONLines:
+		body = append(event, '\n')
+	default:
+		body = event
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.GetEndpoint(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	contentType := s.GetContentType()
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.Authorization != nil {
+		req.Header.Set("Authorization", s.GetAuthorization())
+	}
+	for _, h := range s.CustomHeaders {
+		req.Header.Set(h.GetHeader(), h.GetValue())
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("testing log stream endpoint failed: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("testing log stream endpoint failed: unexpected status %q", res.Status)
+	}
+	return nil
+}
+
+// HTTPCustomHeaders builds the custom headers of a LogStreamSinkHTTP from a
+// map of header keys to values. Headers are sorted by key, so the result is
+// stable across calls.
+func HTTPCustomHeaders(kv map[string]string) []*LogStreamSinkHTTPCustomHeaders {
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	headers := make([]*LogStreamSinkHTTPCustomHeaders, 0, len(keys))
+	for _, k := range keys {
+		headers = append(headers, &LogStreamSinkHTTPCustomHeaders{
+			Header: auth0.String(k),
+			Value:  auth0.String(kv[k]),
+		})
+	}
+	return headers
+}
+
+// SetHeader sets the custom header key to value, replacing the existing
+// value if the header is already set. As Auth0 rejects duplicate header keys,
+// any other header with the same key is removed. Keys are compared case
+// insensitively and the position of existing headers is preserved.
+func (s *LogStreamSinkHTTP) SetHeader(key, value string) {
+	headers := s.CustomHeaders[:0]
+	found := false
+	for _, h := range s.CustomHeaders {
+		if !strings.EqualFold(h.GetHeader(), key) {
+			headers = append(headers, h)
+			continue
+		}
+		if !found {
+			h.Header = auth0.String(key)
+			h.Value = auth0.String(value)
+			headers = append(headers, h)
+			found = true
+		}
+	}
+	if !found {
+		headers = append(headers, &LogStreamSinkHTTPCustomHeaders{
+			Header: auth0.String(key),
+			Value:  auth0.String(value),
+		})
+	}
+	s.CustomHeaders = headers
+}
+
 // LogStreamSinkDatadog is used to export logs to Datadog.
 type LogStreamSinkDatadog struct {
 	// Datadog Region
 	Region *string `json:"datadogRegion,omitempty"`
 	// Datadog Api Key
 	APIKey *string `json:"datadogApiKey,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// Validate checks that the Region of the sink, if set, is one of the
+// DatadogRegion constants and that its APIKey, if set, is not empty.
+func (s *LogStreamSinkDatadog) Validate() error {
+	if s.Region != nil {
+		valid := false
+		for _, r := range datadogRegions {
+			valid = valid || *s.Region == r
+		}
+		if !valid {
+			return fmt.Errorf("invalid Datadog region %q, must be one of %q", *s.Region, datadogRegions)
+		}
+	}
+	if s.APIKey != nil && *s.APIKey == "" {
+		return errors.New("the Datadog API key must not be empty")
+	}
+	return nil
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkDatadog type.
+func (s *LogStreamSinkDatadog) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkDatadog
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkDatadog type.
+func (s *LogStreamSinkDatadog) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkDatadog
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 // LogStreamSinkSplunk is used to export logs to Splunk.
@@ -168,12 +1011,116 @@ type LogStreamSinkSplunk struct {
 	Port *string `json:"splunkPort,omitempty"`
 	// Splunk Secure
 	Secure *bool `json:"splunkSecure,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// Validate checks that the Port of the sink, if set, is a valid TCP port and
+// that its Domain, if set, is a bare host, without a scheme, port or path.
+func (s *LogStreamSinkSplunk) Validate() error {
+	if s.Port != nil {
+		port, err := strconv.Atoi(*s.Port)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid Splunk port %q, must be a number between 1 and 65535", *s.Port)
+		}
+	}
+	if s.Domain != nil {
+		d := *s.Domain
+		if d == "" || strings.Contains(d, "://") || strings.ContainsAny(d, "/:?# ") {
+			return fmt.Errorf("invalid Splunk domain %q, must be a host name such as %q", d, "demo.splunk.com")
+		}
+	}
+	return nil
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkSplunk type.
+func (s *LogStreamSinkSplunk) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkSplunk
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkSplunk type.
+func (s *LogStreamSinkSplunk) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkSplunk
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 // LogStreamSinkSumo is used to export logs to Sumo Logic.
 type LogStreamSinkSumo struct {
 	// Sumo Source Address
 	SourceAddress *string `json:"sumoSourceAddress,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkSumo type.
+func (s *LogStreamSinkSumo) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkSumo
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkSumo type.
+func (s *LogStreamSinkSumo) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkSumo
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
+}
+
+// LogStreamSinkMixpanel is used to export logs to Mixpanel.
+type LogStreamSinkMixpanel struct {
+	// Mixpanel Region
+	Region *string `json:"mixpanelRegion,omitempty"`
+	// Mixpanel Project Id
+	ProjectID *string `json:"mixpanelProjectId,omitempty"`
+	// Mixpanel Service Account Username
+	ServiceAccountUsername *string `json:"mixpanelServiceAccountUsername,omitempty"`
+	// Mixpanel Service Account Password
+	ServiceAccountPassword *string `json:"mixpanelServiceAccountPassword,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkMixpanel type.
+func (s *LogStreamSinkMixpanel) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkMixpanel
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkMixpanel type.
+func (s *LogStreamSinkMixpanel) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkMixpanel
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
+}
+
+// LogStreamSinkSegment is used to export logs to Segment.
+type LogStreamSinkSegment struct {
+	// Segment Write Key
+	WriteKey *string `json:"segmentWriteKey,omitempty"`
+
+	// Extra holds any sink properties not explicitly modeled by the SDK. They
+	// are preserved when a log stream is read and sent back on update.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the LogStreamSinkSegment type.
+func (s *LogStreamSinkSegment) MarshalJSON() ([]byte, error) {
+	type sink LogStreamSinkSegment
+	return marshalWithExtra((*sink)(s), s.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the LogStreamSinkSegment type.
+func (s *LogStreamSinkSegment) UnmarshalJSON(b []byte) (err error) {
+	type sink LogStreamSinkSegment
+	s.Extra, err = unmarshalWithExtra(b, (*sink)(s))
+	return err
 }
 
 // LogStreamManager manages Auth0 LogStream resources.
@@ -187,11 +1134,46 @@ func newLogStreamManager(m *Management) *LogStreamManager {
 
 // Create a log stream.
 //
+// The log stream is validated locally before being sent, see
+// LogStream.ValidateSink.
+//
 // See: https://auth0.com/docs/api/management/v2#!/log-streams
 func (m *LogStreamManager) Create(l *LogStream, opts ...RequestOption) error {
+	if err := l.ValidateSink(); err != nil {
+		return err
+	}
 	return m.Request("POST", m.URI("log-streams"), l, opts...)
 }
 
+// CreateBatch creates several log streams, one request each as there is no
+// bulk endpoint. The ID of every log stream created is populated and the
+// created log streams are returned in the order of streams.
+//
+// Failures, identified by the index and name of the log stream, do not stop
+// the other creations and are all returned; use WithConcurrency to create
+// several log streams in parallel.
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams
+func (m *LogStreamManager) CreateBatch(streams []*LogStream, opts ...RequestOption) ([]*LogStream, error) {
+	failed := make([]bool, len(streams))
+	err := forEachConcurrently(len(streams), opts, func(i int) error {
+		if err := m.Create(streams[i], opts...); err != nil {
+			failed[i] = true
+			return fmt.Errorf("creating log stream %d (%q) failed: %w", i, streams[i].GetName(), err)
+		}
+		return nil
+	})
+
+	created := make([]*LogStream, 0, len(streams))
+	for i, l := range streams {
+		if !failed[i] {
+			created = append(created, l)
+		}
+	}
+
+	return created, err
+}
+
 // Read a log stream.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Log_Streams/get_log_streams_by_id
@@ -202,10 +1184,178 @@ func (m *LogStreamManager) Read(id string, opts ...RequestOption) (l *LogStream,
 
 // List all log streams.
 //
+// The results can be narrowed using the WithLogStreamType and
+// WithLogStreamStatus options. As the Auth0 Management API does not support
+// filtering log streams, these filters are applied client side, after all log
+// streams have been retrieved.
+//
 // See: https://auth0.com/docs/api/management/v2#!/log-streams/get_log_streams
 func (m *LogStreamManager) List(opts ...RequestOption) (ls []*LogStream, err error) {
 	err = m.Request("GET", m.URI("log-streams"), &ls, opts...)
-	return
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range opts {
+		if f, ok := o.(*logStreamFilter); ok {
+			ls = f.filter(ls)
+		}
+	}
+	return ls, nil
+}
+
+// LogStreamList is a page of log streams, as returned by
+// LogStreamManager.ListPage.
+type LogStreamList struct {
+	List
+	LogStreams []*LogStream `json:"log_streams"`
+}
+
+// ListPage lists a page of log streams, along with the total amount of log
+// streams, e.g. to build a paginated UI. The page is selected using the Page
+// and PerPage options, and all log streams are returned in a single page
+// unless PerPage is set.
+//
+// As the Auth0 Management API neither paginates nor counts log streams, all
+// log streams are retrieved and the page is computed client side, after the
+// WithLogStreamType and WithLogStreamStatus filters have been applied.
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams/get_log_streams
+func (m *LogStreamManager) ListPage(opts ...RequestOption) (*LogStreamList, error) {
+	r, err := m.NewRequest("GET", m.URI("log-streams"), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+
+	ls, err := m.List(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = len(ls)
+	}
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 0 {
+		page = 0
+	}
+
+	start := page * perPage
+	if start > len(ls) {
+		start = len(ls)
+	}
+	end := start + perPage
+	if end > len(ls) {
+		end = len(ls)
+	}
+
+	return &LogStreamList{
+		List: List{
+			Start:  start,
+			Limit:  perPage,
+			Length: end - start,
+			Total:  len(ls),
+		},
+		LogStreams: ls[start:end],
+	}, nil
+}
+
+// WithLogStreamType configures LogStreamManager.List to only return log
+// streams of the given type, e.g. LogStreamTypeHTTP.
+func WithLogStreamType(t string) RequestOption {
+	return &logStreamFilter{match: func(l *LogStream) bool {
+		return l.TypeEnum() == LogStreamType(t)
+	}}
+}
+
+// WithLogStreamStatus configures LogStreamManager.List to only return log
+// streams with the given status, e.g. LogStreamStatusActive.
+func WithLogStreamStatus(s string) RequestOption {
+	return &logStreamFilter{match: func(l *LogStream) bool {
+		return l.GetStatus() == s
+	}}
+}
+
+// logStreamFilter is a RequestOption which filters the results of
+// LogStreamManager.List. It leaves the request itself untouched.
+type logStreamFilter struct {
+	match func(*LogStream) bool
+}
+
+func (f *logStreamFilter) apply(*http.Request) {}
+
+func (f *logStreamFilter) filter(ls []*LogStream) []*LogStream {
+	var matches []*LogStream
+	for _, l := range ls {
+		if f.match(l) {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
+// ReadByName retrieves the log stream with the given name.
+//
+// An Error with a 404 status code is returned if no log stream has the given
+// name. As names are not guaranteed to be unique, an error is also returned if
+// more than one log stream has the given name.
+func (m *LogStreamManager) ReadByName(name string, opts ...RequestOption) (*LogStream, error) {
+	ls, err := m.listByName(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(ls) == 0 {
+		return nil, &ManagementError{
+			StatusCode: http.StatusNotFound,
+			Err:        http.StatusText(http.StatusNotFound),
+			Message:    fmt.Sprintf("log stream %q does not exist", name),
+		}
+	}
+	return ls[0], nil
+}
+
+// CreateIfNotExists creates the log stream unless a log stream with the same
+// name already exists, in which case l is overwritten with the existing one.
+// The returned boolean reports whether the log stream was created.
+//
+// An error is returned if more than one log stream has the same name.
+func (m *LogStreamManager) CreateIfNotExists(l *LogStream, opts ...RequestOption) (bool, error) {
+	ls, err := m.listByName(l.GetName(), opts...)
+	if err != nil {
+		return false, err
+	}
+	if len(ls) == 1 {
+		*l = *ls[0]
+		return false, nil
+	}
+	if err := m.Create(l, opts...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// listByName returns the log streams with the given name, failing if there
+// is more than one.
+func (m *LogStreamManager) listByName(name string, opts ...RequestOption) ([]*LogStream, error) {
+	ls, err := m.List(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*LogStream
+	for _, l := range ls {
+		if l.GetName() == name {
+			matches = append(matches, l)
+		}
+	}
+
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("found %d log streams named %q, expected at most 1", len(matches), name)
+	}
+
+	return matches, nil
 }
 
 // Update a log stream.
@@ -213,16 +1363,74 @@ func (m *LogStreamManager) List(opts ...RequestOption) (ls []*LogStream, err err
 // The following fields may be updated in a PATCH operation: Name, Status, Sink.
 //
 // Note: For log streams of type eventbridge and eventgrid, updating the sink is
-// not permitted.
+// not permitted and an error is returned without contacting the API. The same
+// applies to setting the read-only "suspended" status.
+//
+// The sink is checked to match the Type of the log stream, see
+// LogStream.Validate. As a PATCH operation only needs to include the sink
+// fields being changed, its required fields are not checked.
 //
 // See: https://auth0.com/docs/api/management/v2#!/log-streams
 func (m *LogStreamManager) Update(id string, l *LogStream, opts ...RequestOption) (err error) {
+	if err := l.validateUpdate(); err != nil {
+		return err
+	}
 	return m.Request("PATCH", m.URI("log-streams", id), l, opts...)
 }
 
+// Pause a log stream by setting its status to "paused".
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams
+func (m *LogStreamManager) Pause(id string, opts ...RequestOption) error {
+	return m.SetStatus(id, LogStreamStatusPaused, opts...)
+}
+
+// Resume a log stream by setting its status to "active".
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams
+func (m *LogStreamManager) Resume(id string, opts ...RequestOption) error {
+	return m.SetStatus(id, LogStreamStatusActive, opts...)
+}
+
+// SetStatus updates only the status of a log stream. The status must be either
+// "active" or "paused", otherwise an error is returned without contacting the
+// API. In particular, the "suspended" status is set by Auth0 and is read-only.
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams
+func (m *LogStreamManager) SetStatus(id, status string, opts ...RequestOption) error {
+	switch status {
+	case LogStreamStatusActive, LogStreamStatusPaused:
+	default:
+		return fmt.Errorf("invalid log stream status %q, must be either %q or %q",
+			status, LogStreamStatusActive, LogStreamStatusPaused)
+	}
+	return m.Update(id, &LogStream{Status: &status}, opts...)
+}
+
 // Delete a log stream.
 //
 // See: https://auth0.com/docs/api/management/v2#!/log-streams
 func (m *LogStreamManager) Delete(id string, opts ...RequestOption) (err error) {
 	return m.Request("DELETE", m.URI("log-streams", id), nil, opts...)
 }
+
+// DeleteAll deletes all log streams, or only those matching the
+// WithLogStreamType and WithLogStreamStatus options.
+//
+// Failures do not stop the other deletions and are all returned; use
+// WithConcurrency to delete several log streams in parallel.
+//
+// See: https://auth0.com/docs/api/management/v2#!/log-streams
+func (m *LogStreamManager) DeleteAll(opts ...RequestOption) error {
+	ls, err := m.List(opts...)
+	if err != nil {
+		return err
+	}
+
+	return forEachConcurrently(len(ls), opts, func(i int) error {
+		if err := m.Delete(ls[i].GetID(), opts...); err != nil {
+			return fmt.Errorf("deleting log stream %q failed: %w", ls[i].GetID(), err)
+		}
+		return nil
+	})
+}
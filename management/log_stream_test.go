@@ -1,6 +1,17 @@
 package management
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -63,6 +74,10 @@ func TestLogStream(t *testing.T) {
 				_, ok = ls.Sink.(*LogStreamSinkSplunk)
 			case LogStreamTypeSumo:
 				_, ok = ls.Sink.(*LogStreamSinkSumo)
+			case LogStreamTypeMixpanel:
+				_, ok = ls.Sink.(*LogStreamSinkMixpanel)
+			case LogStreamTypeSegment:
+				_, ok = ls.Sink.(*LogStreamSinkSegment)
 			default:
 				_, ok = ls.Sink.(map[string]interface{})
 			}
@@ -95,6 +110,20 @@ func TestLogStream(t *testing.T) {
 		t.Logf("%v\n", l)
 	})
 
+	t.Run("Pause", func(t *testing.T) {
+		err = m.LogStream.Pause(l.GetID())
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		err = m.LogStream.Resume(l.GetID())
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		err = m.LogStream.Delete(l.GetID())
 		if err != nil {
@@ -299,3 +328,990 @@ func TestLogStreamSink(t *testing.T) {
 		t.Logf("%s\n", l)
 	})
 }
+
+func TestLogStreamValidate(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		ls    *LogStream
+		valid bool
+	}{
+		{
+			name: "matching sink",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeDatadog),
+				Sink: &LogStreamSinkDatadog{},
+			},
+			valid: true,
+		},
+		{
+			name: "mismatched sink",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeDatadog),
+				Sink: &LogStreamSinkSplunk{},
+			},
+			valid: false,
+		},
+		{
+			name: "unknown type",
+			ls: &LogStream{
+				Type: auth0.String("unknown"),
+				Sink: map[string]interface{}{"foo": "bar"},
+			},
+			valid: true,
+		},
+		{
+			name:  "no sink",
+			ls:    &LogStream{Type: auth0.String(LogStreamTypeHTTP)},
+			valid: true,
+		},
+		{
+			name: "known content format",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeHTTP),
+				Sink: &LogStreamSinkHTTP{ContentFormat: auth0.String(LogStreamHTTPContentFormatJSONLines)},
+			},
+			valid: true,
+		},
+		{
+			name: "unknown content format",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeHTTP),
+				Sink: &LogStreamSinkHTTP{ContentFormat: auth0.String("JSONLINE")},
+			},
+			valid: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.ls.Validate()
+			expect.Expect(t, err == nil, test.valid)
+		})
+	}
+}
+
+func TestLogStreamValidateSink(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ls   *LogStream
+		err  string
+	}{
+		{
+			name: "complete sink",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeDatadog),
+				Sink: &LogStreamSinkDatadog{
+					APIKey: auth0.String("12334567876543"),
+					Region: auth0.String("eu"),
+				},
+			},
+		},
+		{
+			name: "missing fields",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeSplunk),
+				Sink: &LogStreamSinkSplunk{Domain: auth0.String("demo.splunk.com")},
+			},
+			err: "log stream sink *management.LogStreamSinkSplunk is missing required fields: splunkToken, splunkPort",
+		},
+		{
+			name: "mismatched sink",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeDatadog),
+				Sink: &LogStreamSinkSumo{SourceAddress: auth0.String("https://example.com")},
+			},
+			err: `log stream of type "datadog" requires a sink of type *management.LogStreamSinkDatadog, got *management.LogStreamSinkSumo`,
+		},
+		{
+			name: "untyped sink",
+			ls: &LogStream{
+				Type: auth0.String("unknown"),
+				Sink: map[string]interface{}{},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.ls.ValidateSink()
+			if test.err == "" {
+				expect.Expect(t, err, nil)
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			expect.Expect(t, err.Error(), test.err)
+		})
+	}
+
+	m, err := New("example.com", WithInsecure(), WithClient(&http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Error("unexpected request to the Auth0 Management API")
+			return nil, errors.New("unexpected request")
+		}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.LogStream.Create(&LogStream{
+		Type: auth0.String(LogStreamTypeDatadog),
+		Sink: &LogStreamSinkDatadog{Region: auth0.String("eu")},
+	})
+	if err == nil {
+		t.Error("expected an incomplete sink to be rejected")
+	}
+}
+
+func TestLogStreamValidateUpdate(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		ls    *LogStream
+		valid bool
+	}{
+		{
+			name:  "status only",
+			ls:    &LogStream{Status: auth0.String("paused")},
+			valid: true,
+		},
+		{
+			name:  "datadog sink",
+			ls:    &LogStream{Sink: &LogStreamSinkDatadog{}},
+			valid: true,
+		},
+		{
+			name:  "eventbridge sink",
+			ls:    &LogStream{Sink: &LogStreamSinkAmazonEventBridge{}},
+			valid: false,
+		},
+		{
+			name: "eventgrid type",
+			ls: &LogStream{
+				Type: auth0.String(LogStreamTypeAzureEventGrid),
+				Sink: map[string]interface{}{},
+			},
+			valid: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.ls.validateUpdate()
+			expect.Expect(t, err == nil, test.valid)
+		})
+	}
+}
+
+func TestLogStreamSinkMixpanelJSON(t *testing.T) {
+	l := &LogStream{
+		Name: auth0.String("Test-LogStream-Mixpanel"),
+		Type: auth0.String(LogStreamTypeMixpanel),
+		Sink: &LogStreamSinkMixpanel{
+			Region:                 auth0.String("us"),
+			ProjectID:              auth0.String("123456789"),
+			ServiceAccountUsername: auth0.String("fake-account.123abc.mp-service-account"),
+			ServiceAccountPassword: auth0.String("8iwyKSzwV2brfakepassGGKhsZ3INozo"),
+		},
+	}
+
+	b, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual LogStream
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := actual.Sink.(*LogStreamSinkMixpanel)
+	if !ok {
+		t.Fatalf("unexpected type %T", actual.Sink)
+	}
+
+	expect.Expect(t, actual.GetType(), LogStreamTypeMixpanel)
+	expect.Expect(t, s, l.Sink)
+}
+
+func TestLogStreamSinkSegmentJSON(t *testing.T) {
+	l := &LogStream{
+		Name: auth0.String("Test-LogStream-Segment"),
+		Type: auth0.String(LogStreamTypeSegment),
+		Sink: &LogStreamSinkSegment{
+			WriteKey: auth0.String("abcdefghijklmnopqrstuvwxyz"),
+		},
+	}
+	if err := l.ValidateSink(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, string(b), `{"name":"Test-LogStream-Segment","type":"segment","sink":{"segmentWriteKey":"abcdefghijklmnopqrstuvwxyz"}}`)
+
+	var actual LogStream
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := actual.SegmentSink()
+	if !ok {
+		t.Fatalf("unexpected type %T", actual.Sink)
+	}
+	expect.Expect(t, s, l.Sink)
+
+	l.Sink = &LogStreamSinkSegment{}
+	err = l.ValidateSink()
+	expect.Expect(t, err.Error(), "log stream sink *management.LogStreamSinkSegment is missing required fields: segmentWriteKey")
+
+	l.Sink = &LogStreamSinkHTTP{}
+	if err := l.Validate(); err == nil {
+		t.Error("expected a segment log stream with an HTTP sink to be invalid")
+	}
+}
+
+func TestLogStreamSinkAccessors(t *testing.T) {
+	l := &LogStream{
+		Type: auth0.String(LogStreamTypeHTTP),
+		Sink: &LogStreamSinkHTTP{Endpoint: auth0.String("https://example.com/logs")},
+	}
+
+	s, ok := l.HTTPSink()
+	expect.Expect(t, ok, true)
+	expect.Expect(t, s.GetEndpoint(), "https://example.com/logs")
+
+	d, ok := l.DatadogSink()
+	expect.Expect(t, ok, false)
+	expect.Expect(t, d == nil, true)
+}
+
+func TestLogStreamSinkSumoExtra(t *testing.T) {
+	b := []byte(`{"type":"sumo","sink":{"sumoSourceAddress":"https://example.com","sumoRegion":"eu"}}`)
+
+	var l LogStream
+	if err := json.Unmarshal(b, &l); err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := l.SumoSink()
+	if !ok {
+		t.Fatalf("unexpected type %T", l.Sink)
+	}
+
+	expect.Expect(t, s.GetSourceAddress(), "https://example.com")
+	expect.Expect(t, s.Extra, map[string]interface{}{"sumoRegion": "eu"})
+
+	actual, err := json.Marshal(&l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, string(actual), `{"type":"sumo","sink":{"sumoRegion":"eu","sumoSourceAddress":"https://example.com"}}`)
+}
+
+func TestLogStreamSinkExtra(t *testing.T) {
+	b := []byte(`{"type":"datadog","sink":{"datadogRegion":"eu","datadogApiKey":"123","datadogTags":["a"]}}`)
+
+	var l LogStream
+	if err := json.Unmarshal(b, &l); err != nil {
+		t.Fatal(err)
+	}
+
+	s, ok := l.DatadogSink()
+	if !ok {
+		t.Fatalf("unexpected type %T", l.Sink)
+	}
+
+	expect.Expect(t, s.GetRegion(), "eu")
+	expect.Expect(t, s.Extra, map[string]interface{}{"datadogTags": []interface{}{"a"}})
+
+	s.Region = auth0.String("us")
+
+	actual, err := json.Marshal(&LogStream{Sink: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, string(actual), `{"sink":{"datadogApiKey":"123","datadogRegion":"us","datadogTags":["a"]}}`)
+}
+
+func TestLogStreamManagerUpdateStatus(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		expect.Expect(t, r.Method, "PATCH")
+		expect.Expect(t, r.URL.Path, "/api/v2/log-streams/lst_123")
+		expect.Expect(t, string(b), `{"status":"paused"}`+"\n")
+		w.Write([]byte(`{"id":"lst_123","status":"paused"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.LogStream.Pause("lst_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []string{"stopped", LogStreamStatusSuspended} {
+		err = m.LogStream.SetStatus("lst_123", status)
+		if err == nil {
+			t.Errorf("expected status %q to be rejected", status)
+		}
+	}
+}
+
+func TestLogStreamSuspended(t *testing.T) {
+	for _, test := range []struct {
+		status    string
+		suspended bool
+		updatable bool
+	}{
+		{LogStreamStatusActive, false, true},
+		{LogStreamStatusPaused, false, true},
+		{LogStreamStatusSuspended, true, false},
+	} {
+		t.Run(test.status, func(t *testing.T) {
+			l := &LogStream{Status: auth0.String(test.status)}
+			expect.Expect(t, l.IsSuspended(), test.suspended)
+			expect.Expect(t, l.validateUpdate() == nil, test.updatable)
+		})
+	}
+}
+
+func TestLogStreamManagerByName(t *testing.T) {
+	created := false
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[
+				{"id":"lst_1","name":"foo","type":"http","sink":{}},
+				{"id":"lst_2","name":"bar","type":"http","sink":{}},
+				{"id":"lst_3","name":"bar","type":"http","sink":{}}
+			]`))
+		case "POST":
+			created = true
+			w.Write([]byte(`{"id":"lst_4","name":"baz","type":"http","sink":{}}`))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := m.LogStream.ReadByName("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, l.GetID(), "lst_1")
+
+	_, err = m.LogStream.ReadByName("bar")
+	if err == nil {
+		t.Error("expected duplicate names to be rejected")
+	}
+
+	_, err = m.LogStream.ReadByName("baz")
+	if mErr, ok := err.(Error); !ok || mErr.Status() != http.StatusNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	l = &LogStream{Name: auth0.String("foo"), Type: auth0.String(LogStreamTypeHTTP)}
+	ok, err := m.LogStream.CreateIfNotExists(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, ok, false)
+	expect.Expect(t, created, false)
+	expect.Expect(t, l.GetID(), "lst_1")
+
+	l = &LogStream{Name: auth0.String("baz"), Type: auth0.String(LogStreamTypeHTTP)}
+	ok, err = m.LogStream.CreateIfNotExists(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, ok, true)
+	expect.Expect(t, created, true)
+	expect.Expect(t, l.GetID(), "lst_4")
+}
+
+func TestLogStreamManagerListFilter(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.RawQuery, "")
+		w.Write([]byte(`[
+			{"id":"lst_1","type":"http","status":"active","sink":{}},
+			{"id":"lst_2","type":"datadog","status":"active","sink":{}},
+			{"id":"lst_3","type":"http","status":"paused","sink":{}}
+		]`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		opts []RequestOption
+		ids  []string
+	}{
+		{nil, []string{"lst_1", "lst_2", "lst_3"}},
+		{[]RequestOption{WithLogStreamType(LogStreamTypeHTTP)}, []string{"lst_1", "lst_3"}},
+		{[]RequestOption{WithLogStreamStatus(LogStreamStatusActive)}, []string{"lst_1", "lst_2"}},
+		{[]RequestOption{
+			WithLogStreamType(LogStreamTypeHTTP),
+			WithLogStreamStatus(LogStreamStatusActive),
+		}, []string{"lst_1"}},
+		{[]RequestOption{WithLogStreamType(LogStreamTypeSplunk)}, nil},
+	} {
+		ls, err := m.LogStream.List(test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, l := range ls {
+			ids = append(ids, l.GetID())
+		}
+		expect.Expect(t, ids, test.ids)
+	}
+}
+
+func TestLogStreamManagerListPage(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"id":"lst_1","type":"http","status":"active","sink":{}},
+			{"id":"lst_2","type":"datadog","status":"active","sink":{}},
+			{"id":"lst_3","type":"http","status":"paused","sink":{}}
+		]`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		opts []RequestOption
+		list List
+		ids  []string
+	}{
+		{nil, List{Start: 0, Limit: 3, Length: 3, Total: 3}, []string{"lst_1", "lst_2", "lst_3"}},
+		{[]RequestOption{PerPage(2)}, List{Start: 0, Limit: 2, Length: 2, Total: 3}, []string{"lst_1", "lst_2"}},
+		{[]RequestOption{PerPage(2), Page(1)}, List{Start: 2, Limit: 2, Length: 1, Total: 3}, []string{"lst_3"}},
+		{[]RequestOption{PerPage(2), Page(5)}, List{Start: 3, Limit: 2, Length: 0, Total: 3}, nil},
+		{[]RequestOption{WithLogStreamType(LogStreamTypeHTTP), PerPage(1), Page(1)}, List{Start: 1, Limit: 1, Length: 1, Total: 2}, []string{"lst_3"}},
+	} {
+		l, err := m.LogStream.ListPage(test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, ls := range l.LogStreams {
+			ids = append(ids, ls.GetID())
+		}
+		expect.Expect(t, l.List, test.list)
+		expect.Expect(t, ids, test.ids)
+	}
+
+	l, err := m.LogStream.ListPage(PerPage(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, l.HasNext(), true)
+}
+
+func TestLogStreamSinkHTTPHeaders(t *testing.T) {
+	headers := func(s *LogStreamSinkHTTP) (kv []string) {
+		for _, h := range s.CustomHeaders {
+			kv = append(kv, h.GetHeader()+"="+h.GetValue())
+		}
+		return kv
+	}
+
+	for i := 0; i < 10; i++ {
+		s := &LogStreamSinkHTTP{CustomHeaders: HTTPCustomHeaders(map[string]string{
+			"X-B": "2",
+			"X-A": "1",
+			"X-C": "3",
+		})}
+		expect.Expect(t, headers(s), []string{"X-A=1", "X-B=2", "X-C=3"})
+	}
+
+	s := &LogStreamSinkHTTP{}
+	s.SetHeader("X-A", "1")
+	s.SetHeader("X-B", "2")
+	expect.Expect(t, headers(s), []string{"X-A=1", "X-B=2"})
+
+	s.SetHeader("X-A", "3")
+	expect.Expect(t, headers(s), []string{"X-A=3", "X-B=2"})
+
+	s.CustomHeaders = append(s.CustomHeaders, &LogStreamSinkHTTPCustomHeaders{
+		Header: auth0.String("x-a"),
+		Value:  auth0.String("4"),
+	})
+	s.SetHeader("x-a", "5")
+	expect.Expect(t, headers(s), []string{"x-a=5", "X-B=2"})
+}
+
+func TestLogStreamManagerDeleteAll(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	var inFlight, maxInFlight int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[
+				{"id":"lst_1","type":"http","sink":{}},
+				{"id":"lst_2","type":"http","sink":{}},
+				{"id":"lst_3","type":"datadog","sink":{}},
+				{"id":"lst_4","type":"http","sink":{}}
+			]`))
+		case "DELETE":
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			if r.URL.Path == "/api/v2/log-streams/lst_2" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The log stream does not exist"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.LogStream.DeleteAll(WithLogStreamType(LogStreamTypeHTTP), WithConcurrency(2))
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	expect.Expect(t, len(deleted), 3)
+	expect.Expect(t, atomic.LoadInt32(&maxInFlight) <= 2, true)
+}
+
+func TestLogStreamManagerListFields(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Query().Get("fields"), "id,name,type")
+		expect.Expect(t, r.URL.Query().Get("include_fields"), "true")
+		w.Write([]byte(`[{"id":"lst_1","name":"foo","type":"http"}]`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls, err := m.LogStream.List(IncludeFields("id", "name", "type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, len(ls), 1)
+	expect.Expect(t, ls[0].GetName(), "foo")
+	expect.Expect(t, ls[0].GetType(), LogStreamTypeHTTP)
+	expect.Expect(t, ls[0].Status, (*string)(nil))
+	expect.Expect(t, ls[0].Sink, nil)
+}
+
+func TestLogStreamSinkHTTPTestEndpoint(t *testing.T) {
+	status := http.StatusOK
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "POST")
+		expect.Expect(t, r.Header.Get("Authorization"), "Bearer secret")
+		expect.Expect(t, r.Header.Get("Content-Type"), "application/json")
+		expect.Expect(t, r.Header.Get("X-Foo"), "bar")
+		b, _ := ioutil.ReadAll(r.Body)
+		var events []map[string]interface{}
+		if err := json.Unmarshal(b, &events); err != nil {
+			t.Errorf("expected a JSON array, got %s", b)
+		}
+		w.WriteHeader(status)
+	}))
+	defer s.Close()
+
+	sink := &LogStreamSinkHTTP{
+		Endpoint:      auth0.String(s.URL),
+		Authorization: auth0.String("Bearer secret"),
+		ContentFormat: auth0.String(LogStreamHTTPContentFormatJSONArray),
+		CustomHeaders: HTTPCustomHeaders(map[string]string{"X-Foo": "bar"}),
+	}
+	if err := sink.TestEndpoint(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	status = http.StatusUnauthorized
+	if err := sink.TestEndpoint(context.Background(), s.Client()); err == nil {
+		t.Error("expected a non 2xx status to be an error")
+	}
+
+	if err := (&LogStreamSinkHTTP{}).TestEndpoint(context.Background(), nil); err == nil {
+		t.Error("expected a missing endpoint to be an error")
+	}
+}
+
+func TestLogStreamManagerCreateBatch(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var l map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			t.Error(err)
+		}
+		if l["name"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"Invalid sink"}`))
+			return
+		}
+		l["id"] = fmt.Sprintf("lst_%s", l["name"])
+		json.NewEncoder(w).Encode(l)
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var streams []*LogStream
+	for _, name := range []string{"foo", "bad", "bar"} {
+		streams = append(streams, &LogStream{
+			Name: auth0.String(name),
+			Type: auth0.String(LogStreamTypeHTTP),
+			Sink: &LogStreamSinkHTTP{Endpoint: auth0.String("https://example.com/logs")},
+		})
+	}
+
+	created, err := m.LogStream.CreateBatch(streams, WithConcurrency(2))
+	if err == nil {
+		t.Fatal("expected the failed creation to be reported")
+	}
+	expect.Expect(t, err.Error(), `creating log stream 1 ("bad") failed: 400 Bad Request: Invalid sink`)
+	expect.Expect(t, len(created), 2)
+	expect.Expect(t, created[0].GetID(), "lst_foo")
+	expect.Expect(t, created[1].GetID(), "lst_bar")
+	expect.Expect(t, streams[1].ID, (*string)(nil))
+}
+
+func TestLogStreamFilters(t *testing.T) {
+	l := &LogStream{
+		Name:    auth0.String("foo"),
+		Type:    auth0.String(LogStreamTypeHTTP),
+		Filters: LogStreamCategoryFilters(LogStreamFilterCategoryAuthLoginFail, LogStreamFilterCategoryUserFail),
+		Sink:    &LogStreamSinkHTTP{Endpoint: auth0.String("https://example.com/logs")},
+	}
+
+	b, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, string(b), `{"name":"foo","type":"http","filters":[{"type":"category","name":"auth.login.fail"},{"type":"category","name":"user.fail"}],"sink":{"httpEndpoint":"https://example.com/logs"}}`)
+
+	var decoded *LogStream
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, decoded.Filters, l.Filters)
+
+	c := l.Clone()
+	expect.Expect(t, c.Filters, l.Filters)
+	c.Filters[0].Name = auth0.String(LogStreamFilterCategoryOther)
+	expect.Expect(t, l.Filters[0].GetName(), LogStreamFilterCategoryAuthLoginFail)
+
+	patch, err := LogStreamPatch(l, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, patch.Filters, c.Filters)
+
+	patch, err = LogStreamPatch(l, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, patch.Filters, []*LogStreamFilter(nil))
+}
+
+func TestLogStreamPIIConfigValidate(t *testing.T) {
+	for _, test := range []struct {
+		config *LogStreamPIIConfig
+		err    string
+	}{
+		{&LogStreamPIIConfig{LogFields: []string{"email", "phone"}, Method: auth0.String("mask")}, ""},
+		{&LogStreamPIIConfig{LogFields: []string{"email"}, Method: auth0.String("hash"), Algorithm: auth0.String("xxhash")}, ""},
+		{&LogStreamPIIConfig{LogFields: []string{"email"}, Method: auth0.String("hash")}, ""},
+		{&LogStreamPIIConfig{Method: auth0.String("mask")}, "the PII configuration must list at least one log field"},
+		{&LogStreamPIIConfig{LogFields: []string{"ssn"}, Method: auth0.String("mask")}, `invalid PII log field "ssn", must be one of ["first_name" "last_name" "username" "email" "phone" "address"]`},
+		{&LogStreamPIIConfig{LogFields: []string{"email"}, Method: auth0.String("mask"), Algorithm: auth0.String("xxhash")}, `the PII algorithm can only be set with the "hash" method`},
+		{&LogStreamPIIConfig{LogFields: []string{"email"}, Method: auth0.String("hash"), Algorithm: auth0.String("md5")}, `invalid PII algorithm "md5", must be "xxhash"`},
+		{&LogStreamPIIConfig{LogFields: []string{"email"}}, `invalid PII method "", must be "mask" or "hash"`},
+	} {
+		l := &LogStream{Type: auth0.String(LogStreamTypeHTTP), PIIConfig: test.config}
+		err := l.Validate()
+		if test.err == "" {
+			expect.Expect(t, err, nil)
+			continue
+		}
+		if err == nil {
+			t.Errorf("expected error %q, got nil", test.err)
+			continue
+		}
+		expect.Expect(t, err.Error(), test.err)
+	}
+
+	b, err := json.Marshal(&LogStream{PIIConfig: &LogStreamPIIConfig{
+		LogFields: []string{LogStreamPIIFieldEmail},
+		Method:    auth0.String(LogStreamPIIMethodHash),
+		Algorithm: auth0.String(LogStreamPIIAlgorithmXXHash),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, string(b), `{"pii_config":{"log_fields":["email"],"method":"hash","algorithm":"xxhash"}}`)
+}
+
+func TestLogStreamSinkHTTPWithTLSConfig(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	sink := &LogStreamSinkHTTP{Endpoint: auth0.String(s.URL)}
+	if err := sink.TestEndpoint(context.Background(), nil); err == nil {
+		t.Error("expected a certificate signed by an unknown authority to be an error")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(s.Certificate())
+	err := sink.WithTLSConfig(&tls.Config{RootCAs: pool}).TestEndpoint(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, string(b), fmt.Sprintf(`{"httpEndpoint":%q}`, s.URL))
+}
+
+func TestLogStreamClone(t *testing.T) {
+	l := &LogStream{
+		ID:     auth0.String("lst_123"),
+		Name:   auth0.String("prod"),
+		Type:   auth0.String(LogStreamTypeHTTP),
+		Status: auth0.String(LogStreamStatusActive),
+		Sink: &LogStreamSinkHTTP{
+			Endpoint:      auth0.String("https://example.com/logs"),
+			CustomHeaders: HTTPCustomHeaders(map[string]string{"X-Env": "prod"}),
+			Extra:         map[string]interface{}{"httpFoo": "bar"},
+		},
+	}
+
+	c := l.Clone()
+	expect.Expect(t, c.ID, (*string)(nil))
+	expect.Expect(t, c.Status, (*string)(nil))
+	expect.Expect(t, c.GetName(), "prod")
+	expect.Expect(t, c.GetType(), LogStreamTypeHTTP)
+
+	s, ok := c.HTTPSink()
+	if !ok {
+		t.Fatalf("unexpected sink type %T", c.Sink)
+	}
+	expect.Expect(t, s.GetEndpoint(), "https://example.com/logs")
+	expect.Expect(t, s.Extra, map[string]interface{}{"httpFoo": "bar"})
+
+	*c.Name = "staging"
+	s.SetHeader("X-Env", "staging")
+	s.Extra["httpFoo"] = "baz"
+
+	orig, _ := l.HTTPSink()
+	expect.Expect(t, l.GetName(), "prod")
+	expect.Expect(t, orig.CustomHeaders[0].GetValue(), "prod")
+	expect.Expect(t, orig.Extra["httpFoo"], "bar")
+
+	m := (&LogStream{Sink: map[string]interface{}{"foo": "bar"}}).Clone()
+	expect.Expect(t, m.Sink, map[string]interface{}{"foo": "bar"})
+}
+
+func TestLogStreamSinkSplunkValidate(t *testing.T) {
+	for _, test := range []struct {
+		sink  *LogStreamSinkSplunk
+		valid bool
+	}{
+		{&LogStreamSinkSplunk{}, true},
+		{&LogStreamSinkSplunk{Domain: auth0.String("demo.splunk.com"), Port: auth0.String("8088")}, true},
+		{&LogStreamSinkSplunk{Port: auth0.String("")}, false},
+		{&LogStreamSinkSplunk{Port: auth0.String("http")}, false},
+		{&LogStreamSinkSplunk{Port: auth0.String("0")}, false},
+		{&LogStreamSinkSplunk{Port: auth0.String("65536")}, false},
+		{&LogStreamSinkSplunk{Port: auth0.String("-1")}, false},
+		{&LogStreamSinkSplunk{Port: auth0.String("80 80")}, false},
+		{&LogStreamSinkSplunk{Domain: auth0.String("https://demo.splunk.com")}, false},
+		{&LogStreamSinkSplunk{Domain: auth0.String("demo.splunk.com:8088")}, false},
+		{&LogStreamSinkSplunk{Domain: auth0.String("demo.splunk.com/services")}, false},
+		{&LogStreamSinkSplunk{Domain: auth0.String("")}, false},
+	} {
+		err := test.sink.Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("unexpected validation result for %s: %v", Stringify(test.sink), err)
+		}
+	}
+
+	l := &LogStream{
+		Type: auth0.String(LogStreamTypeSplunk),
+		Sink: &LogStreamSinkSplunk{Port: auth0.String("http")},
+	}
+	if err := l.Validate(); err == nil {
+		t.Error("expected the log stream to be invalid")
+	}
+}
+
+func TestLogStreamSinkDatadogValidate(t *testing.T) {
+	for _, test := range []struct {
+		sink  *LogStreamSinkDatadog
+		valid bool
+	}{
+		{&LogStreamSinkDatadog{}, true},
+		{&LogStreamSinkDatadog{Region: auth0.String(DatadogRegionUS1), APIKey: auth0.String("123")}, true},
+		{&LogStreamSinkDatadog{Region: auth0.String(DatadogRegionUS1FED)}, true},
+		{&LogStreamSinkDatadog{Region: auth0.String("us-west-2")}, false},
+		{&LogStreamSinkDatadog{Region: auth0.String("")}, false},
+		{&LogStreamSinkDatadog{APIKey: auth0.String("")}, false},
+	} {
+		err := test.sink.Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("unexpected validation result for %s: %v", Stringify(test.sink), err)
+		}
+	}
+
+	l := &LogStream{
+		Type: auth0.String(LogStreamTypeDatadog),
+		Sink: &LogStreamSinkDatadog{Region: auth0.String("mars")},
+	}
+	err := l.Validate()
+	expect.Expect(t, err.Error(), `invalid Datadog region "mars", must be one of ["us" "us3" "us5" "eu" "us1-fed" "ap1"]`)
+}
+
+func TestLogStreamTypeEnum(t *testing.T) {
+	for _, typ := range []LogStreamType{
+		LogStreamTypeEnumAmazonEventBridge,
+		LogStreamTypeEnumAzureEventGrid,
+		LogStreamTypeEnumHTTP,
+		LogStreamTypeEnumDatadog,
+		LogStreamTypeEnumSplunk,
+		LogStreamTypeEnumSumo,
+		LogStreamTypeEnumMixpanel,
+		LogStreamTypeEnumSegment,
+	} {
+		l := &LogStream{Type: auth0.String(string(typ))}
+		expect.Expect(t, l.TypeEnum(), typ)
+	}
+	expect.Expect(t, (&LogStream{}).TypeEnum(), LogStreamType(""))
+}
+
+func TestLogStreamPatch(t *testing.T) {
+	current := &LogStream{
+		ID:     auth0.String("lst_123"),
+		Name:   auth0.String("logs"),
+		Type:   auth0.String(LogStreamTypeHTTP),
+		Status: auth0.String(LogStreamStatusActive),
+		Sink: &LogStreamSinkHTTP{
+			Endpoint:      auth0.String("https://example.com/logs"),
+			ContentType:   auth0.String("application/json"),
+			Authorization: auth0.String("Bearer token"),
+			Extra:         map[string]interface{}{"httpTimeout": 10.0},
+		},
+	}
+
+	for _, test := range []struct {
+		name     string
+		desired  *LogStream
+		expected string
+	}{
+		{
+			"Unchanged",
+			current.Clone(),
+			`{}`,
+		},
+		{
+			"Name",
+			&LogStream{Name: auth0.String("audit"), Status: auth0.String(LogStreamStatusActive)},
+			`{"name":"audit"}`,
+		},
+		{
+			"Sink",
+			&LogStream{
+				Type: auth0.String(LogStreamTypeHTTP),
+				Sink: &LogStreamSinkHTTP{
+					Endpoint:    auth0.String("https://example.com/v2/logs"),
+					ContentType: auth0.String("application/json"),
+				},
+			},
+			`{"sink":{"httpEndpoint":"https://example.com/v2/logs"}}`,
+		},
+		{
+			"SinkExtra",
+			&LogStream{Sink: &LogStreamSinkHTTP{Extra: map[string]interface{}{"httpTimeout": 30.0}}},
+			`{"sink":{"httpTimeout":30}}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			patch, err := LogStreamPatch(current, test.desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect.Expect(t, string(b), test.expected)
+		})
+	}
+
+	t.Run("Type", func(t *testing.T) {
+		_, err := LogStreamPatch(current, &LogStream{Type: auth0.String(LogStreamTypeSumo)})
+		expect.Expect(t, err.Error(), `the type of a log stream cannot be changed from "http" to "sumo"`)
+	})
+
+	eventBridge := &LogStream{
+		Name: auth0.String("events"),
+		Type: auth0.String(LogStreamTypeAmazonEventBridge),
+		Sink: &LogStreamSinkAmazonEventBridge{
+			AccountID: auth0.String("999999999999"),
+			Region:    auth0.String("us-west-2"),
+		},
+	}
+
+	t.Run("EventBridgeUnchanged", func(t *testing.T) {
+		desired := eventBridge.Clone()
+		desired.Name = auth0.String("audit")
+		patch, err := LogStreamPatch(eventBridge, desired)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, patch.GetName(), "audit")
+		expect.Expect(t, patch.Sink, nil)
+	})
+
+	t.Run("EventBridgeChanged", func(t *testing.T) {
+		desired := eventBridge.Clone()
+		desired.Sink.(*LogStreamSinkAmazonEventBridge).Region = auth0.String("eu-west-1")
+		_, err := LogStreamPatch(eventBridge, desired)
+		expect.Expect(t, err.Error(), `updating the sink of a log stream of type "eventbridge" is not permitted`)
+	})
+}
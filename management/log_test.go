@@ -1,9 +1,13 @@
 package management
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestLog(t *testing.T) {
@@ -35,6 +39,16 @@ func TestLog(t *testing.T) {
 		t.Logf("%v\n", log)
 	})
 
+	t.Run("ListCheckpoint", func(t *testing.T) {
+		logs, next, err := m.Log.ListCheckpoint(firstLog.GetLogID(), 5)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(logs) > 0 && next != logs[len(logs)-1].GetLogID() {
+			t.Errorf("unexpected checkpoint %q", next)
+		}
+	})
+
 	t.Run("Search", func(t *testing.T) {
 		// Search by type "Success Exchange" and limit results to 5 entries
 		logs, err := m.Log.List(Parameter("q", `type:"seacft"`), PerPage(5))
@@ -49,3 +63,82 @@ func TestLog(t *testing.T) {
 		}
 	})
 }
+
+func TestLastLogID(t *testing.T) {
+	expect.Expect(t, LastLogID(nil), "")
+	expect.Expect(t, LastLogID([]*Log{
+		{LogID: auth0.String("1")},
+		{LogID: auth0.String("2")},
+	}), "2")
+}
+
+func TestLogManagerListStream(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Query().Get("take"), "3")
+		w.Write([]byte(`[{"log_id":"1"},{"log_id":"2"},{"log_id":"3"}]`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	err = m.Log.ListStream(func(l *Log) error {
+		ids = append(ids, l.GetLogID())
+		return nil
+	}, Take(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, ids, []string{"1", "2", "3"})
+
+	stop := errors.New("stop")
+	ids = nil
+	err = m.Log.ListStream(func(l *Log) error {
+		ids = append(ids, l.GetLogID())
+		return stop
+	}, Take(3))
+	expect.Expect(t, err, stop)
+	expect.Expect(t, ids, []string{"1"})
+}
+
+func TestLogManagerListAll(t *testing.T) {
+	var froms []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Query().Get("take"), "2")
+		from := r.URL.Query().Get("from")
+		froms = append(froms, from)
+		switch from {
+		case "":
+			w.Write([]byte(`[{"log_id":"1"},{"log_id":"2"}]`))
+		case "2":
+			w.Write([]byte(`[{"log_id":"3"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := m.Log.ListAll(Take(2))
+	var ids []string
+	for {
+		l, err := it.Next()
+		if err == ErrIteratorDone {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, l.GetLogID())
+	}
+	expect.Expect(t, ids, []string{"1", "2", "3"})
+	expect.Expect(t, froms, []string{"", "2"})
+	expect.Expect(t, it.Checkpoint(), "3")
+}
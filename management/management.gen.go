@@ -3675,6 +3675,72 @@ func (d *DailyStat) String() string {
 	return Stringify(d)
 }
 
+// GetClientID returns the ClientID field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetClientID() string {
+	if d == nil || d.ClientID == nil {
+		return ""
+	}
+	return *d.ClientID
+}
+
+// GetDeviceID returns the DeviceID field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetDeviceID() string {
+	if d == nil || d.DeviceID == nil {
+		return ""
+	}
+	return *d.DeviceID
+}
+
+// GetDeviceName returns the DeviceName field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetDeviceName() string {
+	if d == nil || d.DeviceName == nil {
+		return ""
+	}
+	return *d.DeviceName
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetID() string {
+	if d == nil || d.ID == nil {
+		return ""
+	}
+	return *d.ID
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetType() string {
+	if d == nil || d.Type == nil {
+		return ""
+	}
+	return *d.Type
+}
+
+// GetUserID returns the UserID field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetUserID() string {
+	if d == nil || d.UserID == nil {
+		return ""
+	}
+	return *d.UserID
+}
+
+// GetValue returns the Value field if it's non-nil, zero value otherwise.
+func (d *DeviceCredential) GetValue() string {
+	if d == nil || d.Value == nil {
+		return ""
+	}
+	return *d.Value
+}
+
+// String returns a string representation of DeviceCredential.
+func (d *DeviceCredential) String() string {
+	return Stringify(d)
+}
+
+// String returns a string representation of DeviceCredentialList.
+func (d *DeviceCredentialList) String() string {
+	return Stringify(d)
+}
+
 // GetCredentials returns the Credentials field.
 func (e *Email) GetCredentials() *EmailCredentials {
 	if e == nil {
@@ -4149,6 +4215,40 @@ func (j *Job) String() string {
 	return Stringify(j)
 }
 
+// String returns a string representation of JobError.
+func (j *JobError) String() string {
+	return Stringify(j)
+}
+
+// GetCode returns the Code field if it's non-nil, zero value otherwise.
+func (j *JobUserError) GetCode() string {
+	if j == nil || j.Code == nil {
+		return ""
+	}
+	return *j.Code
+}
+
+// GetMessage returns the Message field if it's non-nil, zero value otherwise.
+func (j *JobUserError) GetMessage() string {
+	if j == nil || j.Message == nil {
+		return ""
+	}
+	return *j.Message
+}
+
+// GetPath returns the Path field if it's non-nil, zero value otherwise.
+func (j *JobUserError) GetPath() string {
+	if j == nil || j.Path == nil {
+		return ""
+	}
+	return *j.Path
+}
+
+// String returns a string representation of JobUserError.
+func (j *JobUserError) String() string {
+	return Stringify(j)
+}
+
 // String returns a string representation of List.
 func (l *List) String() string {
 	return Stringify(l)
@@ -4247,6 +4347,14 @@ func (l *LogStream) GetName() string {
 	return *l.Name
 }
 
+// GetPIIConfig returns the PIIConfig field.
+func (l *LogStream) GetPIIConfig() *LogStreamPIIConfig {
+	if l == nil {
+		return nil
+	}
+	return l.PIIConfig
+}
+
 // GetStatus returns the Status field if it's non-nil, zero value otherwise.
 func (l *LogStream) GetStatus() string {
 	if l == nil || l.Status == nil {
@@ -4268,6 +4376,53 @@ func (l *LogStream) String() string {
 	return Stringify(l)
 }
 
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (l *LogStreamFilter) GetName() string {
+	if l == nil || l.Name == nil {
+		return ""
+	}
+	return *l.Name
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (l *LogStreamFilter) GetType() string {
+	if l == nil || l.Type == nil {
+		return ""
+	}
+	return *l.Type
+}
+
+// String returns a string representation of LogStreamFilter.
+func (l *LogStreamFilter) String() string {
+	return Stringify(l)
+}
+
+// String returns a string representation of LogStreamList.
+func (l *LogStreamList) String() string {
+	return Stringify(l)
+}
+
+// GetAlgorithm returns the Algorithm field if it's non-nil, zero value otherwise.
+func (l *LogStreamPIIConfig) GetAlgorithm() string {
+	if l == nil || l.Algorithm == nil {
+		return ""
+	}
+	return *l.Algorithm
+}
+
+// GetMethod returns the Method field if it's non-nil, zero value otherwise.
+func (l *LogStreamPIIConfig) GetMethod() string {
+	if l == nil || l.Method == nil {
+		return ""
+	}
+	return *l.Method
+}
+
+// String returns a string representation of LogStreamPIIConfig.
+func (l *LogStreamPIIConfig) String() string {
+	return Stringify(l)
+}
+
 // GetAccountID returns the AccountID field if it's non-nil, zero value otherwise.
 func (l *LogStreamSinkAmazonEventBridge) GetAccountID() string {
 	if l == nil || l.AccountID == nil {
@@ -4413,6 +4568,56 @@ func (l *LogStreamSinkHTTPCustomHeaders) String() string {
 	return Stringify(l)
 }
 
+// GetProjectID returns the ProjectID field if it's non-nil, zero value otherwise.
+func (l *LogStreamSinkMixpanel) GetProjectID() string {
+	if l == nil || l.ProjectID == nil {
+		return ""
+	}
+	return *l.ProjectID
+}
+
+// GetRegion returns the Region field if it's non-nil, zero value otherwise.
+func (l *LogStreamSinkMixpanel) GetRegion() string {
+	if l == nil || l.Region == nil {
+		return ""
+	}
+	return *l.Region
+}
+
+// GetServiceAccountPassword returns the ServiceAccountPassword field if it's non-nil, zero value otherwise.
+func (l *LogStreamSinkMixpanel) GetServiceAccountPassword() string {
+	if l == nil || l.ServiceAccountPassword == nil {
+		return ""
+	}
+	return *l.ServiceAccountPassword
+}
+
+// GetServiceAccountUsername returns the ServiceAccountUsername field if it's non-nil, zero value otherwise.
+func (l *LogStreamSinkMixpanel) GetServiceAccountUsername() string {
+	if l == nil || l.ServiceAccountUsername == nil {
+		return ""
+	}
+	return *l.ServiceAccountUsername
+}
+
+// String returns a string representation of LogStreamSinkMixpanel.
+func (l *LogStreamSinkMixpanel) String() string {
+	return Stringify(l)
+}
+
+// GetWriteKey returns the WriteKey field if it's non-nil, zero value otherwise.
+func (l *LogStreamSinkSegment) GetWriteKey() string {
+	if l == nil || l.WriteKey == nil {
+		return ""
+	}
+	return *l.WriteKey
+}
+
+// String returns a string representation of LogStreamSinkSegment.
+func (l *LogStreamSinkSegment) String() string {
+	return Stringify(l)
+}
+
 // GetDomain returns the Domain field if it's non-nil, zero value otherwise.
 func (l *LogStreamSinkSplunk) GetDomain() string {
 	if l == nil || l.Domain == nil {
@@ -5076,6 +5281,11 @@ func (p *Prompt) String() string {
 	return Stringify(p)
 }
 
+// String returns a string representation of RateLimitInfo.
+func (r *RateLimitInfo) String() string {
+	return Stringify(r)
+}
+
 // GetAllowOfflineAccess returns the AllowOfflineAccess field if it's non-nil, zero value otherwise.
 func (r *ResourceServer) GetAllowOfflineAccess() bool {
 	if r == nil || r.AllowOfflineAccess == nil {
@@ -5563,6 +5773,14 @@ func (t *Tenant) GetSandboxVersion() string {
 	return *t.SandboxVersion
 }
 
+// GetSessionCookie returns the SessionCookie field.
+func (t *Tenant) GetSessionCookie() *TenantSessionCookie {
+	if t == nil {
+		return nil
+	}
+	return t.SessionCookie
+}
+
 // GetSessionLifetime returns the SessionLifetime field if it's non-nil, zero value otherwise.
 func (t *Tenant) GetSessionLifetime() float64 {
 	if t == nil || t.SessionLifetime == nil {
@@ -5679,6 +5897,30 @@ func (t *TenantFlags) GetAllowChangingEnableSSO() bool {
 	return *t.AllowChangingEnableSSO
 }
 
+// GetAllowLegacyDelegationGrantTypes returns the AllowLegacyDelegationGrantTypes field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetAllowLegacyDelegationGrantTypes() bool {
+	if t == nil || t.AllowLegacyDelegationGrantTypes == nil {
+		return false
+	}
+	return *t.AllowLegacyDelegationGrantTypes
+}
+
+// GetAllowLegacyROGrantTypes returns the AllowLegacyROGrantTypes field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetAllowLegacyROGrantTypes() bool {
+	if t == nil || t.AllowLegacyROGrantTypes == nil {
+		return false
+	}
+	return *t.AllowLegacyROGrantTypes
+}
+
+// GetAllowLegacyTokenInfoEndpoint returns the AllowLegacyTokenInfoEndpoint field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetAllowLegacyTokenInfoEndpoint() bool {
+	if t == nil || t.AllowLegacyTokenInfoEndpoint == nil {
+		return false
+	}
+	return *t.AllowLegacyTokenInfoEndpoint
+}
+
 // GetDisableClickjackProtectionHeaders returns the DisableClickjackProtectionHeaders field if it's non-nil, zero value otherwise.
 func (t *TenantFlags) GetDisableClickjackProtectionHeaders() bool {
 	if t == nil || t.DisableClickjackProtectionHeaders == nil {
@@ -5727,6 +5969,14 @@ func (t *TenantFlags) GetEnableDynamicClientRegistration() bool {
 	return *t.EnableDynamicClientRegistration
 }
 
+// GetEnableIDTokenAPI2 returns the EnableIDTokenAPI2 field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetEnableIDTokenAPI2() bool {
+	if t == nil || t.EnableIDTokenAPI2 == nil {
+		return false
+	}
+	return *t.EnableIDTokenAPI2
+}
+
 // GetEnableLegacyLogsSearchV2 returns the EnableLegacyLogsSearchV2 field if it's non-nil, zero value otherwise.
 func (t *TenantFlags) GetEnableLegacyLogsSearchV2() bool {
 	if t == nil || t.EnableLegacyLogsSearchV2 == nil {
@@ -5735,6 +5985,14 @@ func (t *TenantFlags) GetEnableLegacyLogsSearchV2() bool {
 	return *t.EnableLegacyLogsSearchV2
 }
 
+// GetEnableLegacyProfile returns the EnableLegacyProfile field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetEnableLegacyProfile() bool {
+	if t == nil || t.EnableLegacyProfile == nil {
+		return false
+	}
+	return *t.EnableLegacyProfile
+}
+
 // GetEnablePipeline2 returns the EnablePipeline2 field if it's non-nil, zero value otherwise.
 func (t *TenantFlags) GetEnablePipeline2() bool {
 	if t == nil || t.EnablePipeline2 == nil {
@@ -5759,6 +6017,30 @@ func (t *TenantFlags) GetEnableSSO() bool {
 	return *t.EnableSSO
 }
 
+// GetMFAShowFactorListOnEnrollment returns the MFAShowFactorListOnEnrollment field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetMFAShowFactorListOnEnrollment() bool {
+	if t == nil || t.MFAShowFactorListOnEnrollment == nil {
+		return false
+	}
+	return *t.MFAShowFactorListOnEnrollment
+}
+
+// GetNoDiscloseEnterpriseConnections returns the NoDiscloseEnterpriseConnections field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetNoDiscloseEnterpriseConnections() bool {
+	if t == nil || t.NoDiscloseEnterpriseConnections == nil {
+		return false
+	}
+	return *t.NoDiscloseEnterpriseConnections
+}
+
+// GetRevokeRefreshTokenGrant returns the RevokeRefreshTokenGrant field if it's non-nil, zero value otherwise.
+func (t *TenantFlags) GetRevokeRefreshTokenGrant() bool {
+	if t == nil || t.RevokeRefreshTokenGrant == nil {
+		return false
+	}
+	return *t.RevokeRefreshTokenGrant
+}
+
 // GetUniversalLogin returns the UniversalLogin field if it's non-nil, zero value otherwise.
 func (t *TenantFlags) GetUniversalLogin() bool {
 	if t == nil || t.UniversalLogin == nil {
@@ -5801,6 +6083,19 @@ func (t *TenantGuardianMFAPage) String() string {
 	return Stringify(t)
 }
 
+// GetMode returns the Mode field if it's non-nil, zero value otherwise.
+func (t *TenantSessionCookie) GetMode() string {
+	if t == nil || t.Mode == nil {
+		return ""
+	}
+	return *t.Mode
+}
+
+// String returns a string representation of TenantSessionCookie.
+func (t *TenantSessionCookie) String() string {
+	return Stringify(t)
+}
+
 // GetColors returns the Colors field.
 func (t *TenantUniversalLogin) GetColors() *TenantUniversalLoginColors {
 	if t == nil {
@@ -5867,6 +6162,14 @@ func (t *Ticket) GetEmail() string {
 	return *t.Email
 }
 
+// GetIdentity returns the Identity field.
+func (t *Ticket) GetIdentity() *TicketIdentity {
+	if t == nil {
+		return nil
+	}
+	return t.Identity
+}
+
 // GetIncludeEmailInRedirect returns the IncludeEmailInRedirect field if it's non-nil, zero value otherwise.
 func (t *Ticket) GetIncludeEmailInRedirect() bool {
 	if t == nil || t.IncludeEmailInRedirect == nil {
@@ -5920,6 +6223,27 @@ func (t *Ticket) String() string {
 	return Stringify(t)
 }
 
+// GetProvider returns the Provider field if it's non-nil, zero value otherwise.
+func (t *TicketIdentity) GetProvider() string {
+	if t == nil || t.Provider == nil {
+		return ""
+	}
+	return *t.Provider
+}
+
+// GetUserID returns the UserID field if it's non-nil, zero value otherwise.
+func (t *TicketIdentity) GetUserID() string {
+	if t == nil || t.UserID == nil {
+		return ""
+	}
+	return *t.UserID
+}
+
+// String returns a string representation of TicketIdentity.
+func (t *TicketIdentity) String() string {
+	return Stringify(t)
+}
+
 // GetBlocked returns the Blocked field if it's non-nil, zero value otherwise.
 func (u *User) GetBlocked() bool {
 	if u == nil || u.Blocked == nil {
@@ -3,16 +3,22 @@ package management
 //go:generate go run gen-methods.go
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 
@@ -32,6 +38,10 @@ func WithDebug(d bool) Option {
 
 // WithContext configures the management client to use the provided context
 // instead of the provided one.
+//
+// The context is used when authenticating as well as the default context of
+// every request, unless overridden on a per-request basis using the Context
+// request option.
 func WithContext(ctx context.Context) Option {
 	return func(m *Management) {
 		m.ctx = ctx
@@ -46,19 +56,81 @@ func WithUserAgent(userAgent string) Option {
 	}
 }
 
+// WithUserAgentProduct configures the management client to identify the
+// calling application by appending product and version to the user agent,
+// keeping the identifier of the SDK, e.g. "Go-Auth0-SDK/latest myapp/1.2.3".
+//
+// The product is appended to the user agent set using WithUserAgent, if any,
+// independently of the order of the options.
+func WithUserAgentProduct(product, version string) Option {
+	return func(m *Management) {
+		m.userAgentProduct = product
+		m.userAgentVersion = version
+	}
+}
+
 // WithClientCredentials configures management to authenticate using the client
 // credentials authentication flow.
 func WithClientCredentials(clientID, clientSecret string) Option {
 	return func(m *Management) {
-		m.tokenSource = client.OAuth2ClientCredentials(m.ctx, m.url.String(), clientID, clientSecret)
+		m.clientID = clientID
+		m.clientSecret = clientSecret
+		m.tokenSource = nil
+	}
+}
+
+// WithClientCredentialsAudience configures the audience of the access tokens
+// requested using WithClientCredentials. It defaults to the Management API of
+// the domain passed to New, which only needs to be changed when using a custom
+// domain, as the audience is always the Management API of the tenant domain,
+// e.g. "https://tenant.eu.auth0.com/api/v2/".
+func WithClientCredentialsAudience(audience string) Option {
+	return func(m *Management) {
+		m.audience = audience
+	}
+}
+
+// WithClientCredentialsScopes restricts the access tokens requested using
+// WithClientCredentials to the given scopes, e.g. "read:logs", so that least
+// privilege tokens are used. By default the access tokens hold every scope
+// granted to the client.
+//
+// Requests which require other scopes fail with a 403 status code, which
+// IsInsufficientScope reports.
+func WithClientCredentialsScopes(scopes ...string) Option {
+	return func(m *Management) {
+		m.scopes = scopes
+	}
+}
+
+// WithTokenRefreshMargin configures how long before their expiry the access
+// tokens requested using WithClientCredentials are refreshed. It defaults to
+// a minute.
+func WithTokenRefreshMargin(margin time.Duration) Option {
+	return func(m *Management) {
+		m.tokenMargin = margin
 	}
 }
 
 // WithStaticToken configures management to authenticate using a static
 // authentication token.
+//
+// The token is never refreshed, so requests fail with a 401 status code once
+// it has expired.
 func WithStaticToken(token string) Option {
+	return WithTokenSource(client.StaticToken(token))
+}
+
+// WithTokenSource configures management to authenticate using the access
+// tokens of source, e.g. when the tokens are obtained from an external source.
+// Tokens are never requested from the token endpoint of the tenant.
+//
+// The source is called before every request and is responsible for caching
+// and refreshing the tokens, e.g. using oauth2.ReuseTokenSource. Requests
+// failing with a 401 status code are not retried.
+func WithTokenSource(source oauth2.TokenSource) Option {
 	return func(m *Management) {
-		m.tokenSource = client.StaticToken(token)
+		m.tokenSource = source
 	}
 }
 
@@ -74,7 +146,210 @@ func WithInsecure() Option {
 	}
 }
 
-// WithClient configures management to use the provided client.
+// WithRetries configures management to retry requests which were rate limited
+// or failed with a 503 status code at most maxRetries times, after which the
+// error is returned. Setting maxRetries to 0 disables retries.
+//
+// By default such requests are retried up to 10 times.
+func WithRetries(maxRetries int) Option {
+	return func(m *Management) {
+		m.maxRetries = maxRetries
+	}
+}
+
+// WithRequestInspector configures management to call inspect with every
+// request before it is sent, once its payload has been serialized. This is
+// useful in tests to assert the method, URL, headers and payload of requests.
+//
+// The request passed to inspect is a copy, so reading its body does not
+// affect the request being sent. Note that the request is still sent to the
+// Auth0 Management API after inspect returns. To avoid that, use WithDryRun
+// instead.
+func WithRequestInspector(inspect func(r *http.Request)) Option {
+	return func(m *Management) {
+		m.inspect = inspect
+	}
+}
+
+// ErrDryRun is returned by requests made by a client configured using
+// WithDryRun.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// WithDryRun configures management to not send requests to the Auth0
+// Management API. Instead, every request is passed to capture once it has been
+// fully built, including its access token, and ErrDryRun is returned.
+//
+// The request passed to capture is a copy, so its body can be read freely.
+// Access tokens are still requested from Auth0 when using client credentials.
+// Note that methods which depend on the response of a previous request, such
+// as those iterating over pages, stop after their first request.
+func WithDryRun(capture func(r *http.Request)) Option {
+	return func(m *Management) {
+		m.dryRun = capture
+	}
+}
+
+// Codec marshals request payloads to JSON and unmarshals response payloads
+// from JSON.
+//
+// Implementations are expected to honor the json.Marshaler and
+// json.Unmarshaler interfaces, as types such as LogStream rely on them.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// WithCodec configures management to use the provided codec instead of the
+// encoding/json package, e.g. a faster drop-in replacement.
+//
+// Payloads which implement json.Marshaler or json.Unmarshaler themselves,
+// such as a LogStream, are still encoded and decoded using their own methods.
+// Methods which stream their response, such as UserManager.ListStream, always
+// use the encoding/json package.
+func WithCodec(codec Codec) Option {
+	return func(m *Management) {
+		m.codec = codec
+	}
+}
+
+// WithRequestHook configures management to call hook with every request
+// sent to the Auth0 Management API, including retried requests. The hook is
+// called once the access token has been attached, but the value of the
+// "Authorization" header is redacted.
+//
+// The request passed to hook is a copy, so reading its body does not affect
+// the request being sent.
+func WithRequestHook(hook func(r *http.Request)) Option {
+	return func(m *Management) {
+		m.requestHook = hook
+	}
+}
+
+// WithResponseHook configures management to call hook with every response
+// received from the Auth0 Management API, including rate limited responses
+// which are retried.
+//
+// The response passed to hook is a copy, so reading its body does not affect
+// the decoding of the response.
+func WithResponseHook(hook func(r *http.Response)) Option {
+	return func(m *Management) {
+		m.responseHook = hook
+	}
+}
+
+// Logger is used to record the requests sent to the Auth0 Management API,
+// e.g. for auditing purposes.
+type Logger interface {
+	// Log records a request once its response has been received or it failed.
+	// The fields are "method", "path", "query", "header", "status" and
+	// "duration", as well as "error" when the request failed.
+	Log(ctx context.Context, fields map[string]interface{})
+}
+
+// noopLogger is the Logger used unless one is configured using WithLogger.
+type noopLogger struct{}
+
+func (noopLogger) Log(context.Context, map[string]interface{}) {}
+
+// WithLogger configures management to record every request sent to the Auth0
+// Management API using logger. Retries of rate limited requests are recorded
+// as a single request.
+//
+// The value of the "Authorization" header, and of any header or query
+// parameter whose name contains "token" or "secret", is redacted.
+func WithLogger(logger Logger) Option {
+	return func(m *Management) {
+		if logger == nil {
+			logger = noopLogger{}
+		}
+		m.logger = logger
+	}
+}
+
+// Tracer creates the spans used to trace the requests sent to the Auth0
+// Management API. It is deliberately small, so that it can be implemented on
+// top of OpenTelemetry, or any other tracing library, without the SDK
+// depending on it, e.g.:
+//
+//   func (t *otelTracer) StartSpan(ctx context.Context, name string) (context.Context, management.Span) {
+//       ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//       return ctx, &otelSpan{span}
+//   }
+//
+//   func (t *otelTracer) Inject(ctx context.Context, h http.Header) {
+//       otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+//   }
+type Tracer interface {
+	// StartSpan starts a span with the given name as a child of the span held
+	// by ctx, if any, and returns a context holding the new span.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+
+	// Inject adds the headers propagating the span held by ctx to h.
+	Inject(ctx context.Context, h http.Header)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute of the span.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records that the traced operation failed with err.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// WithTracing configures management to trace every call to Request using
+// tracer. Spans are named after the HTTP method and the resource requested,
+// e.g. "GET roles", and have the "http.method", "http.url" and
+// "http.status_code" attributes set. The URL attribute omits the query, as it
+// may hold secrets.
+func WithTracing(tracer Tracer) Option {
+	return func(m *Management) {
+		m.tracer = tracer
+	}
+}
+
+// WithDefaultOptions configures management to apply options to every request,
+// before the options passed to the request itself, e.g.:
+//
+//   m, err := management.New(domain,
+//       management.WithClientCredentials(id, secret),
+//       management.WithDefaultOptions(management.IncludeTotals(true), management.PerPage(100)))
+//
+// The options of a request take precedence over the defaults: any query
+// parameter they set replaces all the values set by the defaults for the same
+// parameter, and headers they set replace the default ones. WithTimeout is
+// honored as a default, while options configuring specific methods, such as
+// WithConcurrency, are not and must be passed to each call. A default PerPage
+// replaces the page size of 50 used by the List methods.
+func WithDefaultOptions(options ...RequestOption) Option {
+	return func(m *Management) {
+		m.defaults = append(m.defaults, options...)
+	}
+}
+
+// DefaultMaxBodyBytes is the maximum size of request payloads accepted by the
+// Auth0 Management API.
+const DefaultMaxBodyBytes = 1 << 20
+
+// WithMaxBodyBytes configures management to fail requests whose serialized
+// payload is larger than n bytes before sending them, instead of having them
+// rejected by the Auth0 Management API with a 413 status code. It defaults to
+// DefaultMaxBodyBytes. Setting n to 0 disables the check.
+func WithMaxBodyBytes(n int) Option {
+	return func(m *Management) {
+		m.maxBodyBytes = n
+	}
+}
+
+// WithClient configures management to use the provided client, e.g. one
+// with a custom transport for mutual TLS or tuned connection pooling.
+//
+// Authentication, retries and the user agent are layered on top of the
+// client's transport. When using WithClientCredentials, the client is also
+// used to request access tokens.
 func WithClient(client *http.Client) Option {
 	return func(m *Management) {
 		m.http = client
@@ -83,6 +358,17 @@ func WithClient(client *http.Client) Option {
 
 // Management is an Auth0 management client used to interact with the Auth0
 // Management API v2.
+//
+// A Management, including its managers such as LogStream, is safe for
+// concurrent use by multiple goroutines and should be reused rather than
+// created for each request, so that access tokens and connections are reused.
+// Access tokens obtained using client credentials are cached and refreshed by
+// a single goroutine, and RateLimit may be called while requests are in
+// flight. Functions configured using options, such as WithRequestHook or
+// WithLogger, may be called concurrently and must be safe to do so.
+//
+// Pager and the iterators returned by methods such as UserManager.ListAll
+// hold the state of an iteration and must not be used concurrently.
 type Management struct {
 	// Client manages Auth0 Client (also known as Application) resources.
 	Client *ClientManager
@@ -169,17 +455,48 @@ type Management struct {
 	// AttackProtection manages Auth0 Attack Protection.
 	AttackProtection *AttackProtectionManager
 
+	// DeviceCredential manages Auth0 Device Credentials.
+	DeviceCredential *DeviceCredentialManager
+
 	url         *url.URL
 	basePath    string
 	userAgent   string
 	debug       bool
+	maxRetries  int
 	ctx         context.Context
 	tokenSource oauth2.TokenSource
 	http        *http.Client
+	inspect     func(*http.Request)
+	dryRun      func(*http.Request)
+	codec       Codec
+
+	requestHook  func(*http.Request)
+	responseHook func(*http.Response)
+	logger       Logger
+	tracer       Tracer
+	defaults     []RequestOption
+	maxBodyBytes int
+
+	userAgentProduct string
+	userAgentVersion string
+
+	clientID     string
+	clientSecret string
+	audience     string
+	scopes       []string
+	tokenMargin  time.Duration
+
+	rateLimitMu sync.RWMutex
+	rateLimit   RateLimitInfo
 }
 
 // New creates a new Auth0 Management client by authenticating using the
 // supplied client id and secret.
+//
+// The domain is that of the tenant, e.g. "tenant.eu.auth0.com", or of a
+// custom domain. It may include a path prefix, e.g. for private cloud
+// installations served as "custom.example.com/auth0", in which case the
+// Management API and the token endpoint are expected under that prefix.
 func New(domain string, options ...Option) (*Management, error) {
 	// Ignore the scheme if it was defined in the domain variable. Then prefix
 	// with https as its the only scheme supported by the Auth0 API.
@@ -192,24 +509,68 @@ func New(domain string, options ...Option) (*Management, error) {
 	if err != nil {
 		return nil, err
 	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
 
 	m := &Management{
-		url:       u,
-		basePath:  "api/v2",
-		userAgent: client.UserAgent,
-		debug:     false,
-		ctx:       context.Background(),
-		http:      http.DefaultClient,
+		url:        u,
+		basePath:   "api/v2",
+		userAgent:  client.UserAgent,
+		debug:      false,
+		maxRetries: client.DefaultMaxRetries,
+		ctx:        context.Background(),
+		http:       http.DefaultClient,
+		logger:     noopLogger{},
+
+		maxBodyBytes: DefaultMaxBodyBytes,
+		tokenMargin:  client.DefaultTokenExpiryMargin,
 	}
 
 	for _, option := range options {
 		option(m)
 	}
 
+	if m.userAgentProduct != "" {
+		m.userAgent = fmt.Sprintf("%s %s/%s", m.userAgent, m.userAgentProduct, m.userAgentVersion)
+	}
+
+	// The client credentials token source is created once all options have
+	// been applied, so that it uses the configured context and client
+	// independently of the order of the options. Tokens are requested using
+	// the configured client and user agent, unless the context already
+	// specifies a client.
+	if m.tokenSource == nil && m.clientID != "" {
+		ctx := m.ctx
+		if ctx.Value(oauth2.HTTPClient) == nil {
+			c := *m.http
+			c.Transport = client.UserAgentTransport(c.Transport, m.userAgent)
+			ctx = context.WithValue(ctx, oauth2.HTTPClient, &c)
+		}
+		m.tokenSource = client.OAuth2ClientCredentials(ctx, m.url.String(), m.clientID, m.clientSecret, m.audience, m.scopes, m.tokenMargin)
+	}
+
+	// The dry run transport replaces the transport of the client only after
+	// the token source has been created, so that tokens are still obtained.
+	if m.dryRun != nil {
+		c := *m.http
+		c.Transport = client.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			m.dryRun(cloneRequest(req))
+			return nil, ErrDryRun
+		})
+		m.http = &c
+	}
+
+	// The hooks wrap the transport of the client, so that they are called
+	// after the access token has been attached to requests.
+	if m.requestHook != nil || m.responseHook != nil {
+		c := *m.http
+		c.Transport = client.HookTransport(c.Transport, m.requestHook, m.responseHook)
+		m.http = &c
+	}
+
 	m.http = client.Wrap(m.http, m.tokenSource,
 		client.WithDebug(m.debug),
 		client.WithUserAgent(m.userAgent),
-		client.WithRateLimit())
+		client.WithRetries(m.maxRetries))
 
 	m.Client = newClientManager(m)
 	m.ClientGrant = newClientGrantManager(m)
@@ -239,6 +600,7 @@ func New(domain string, options ...Option) (*Management, error) {
 	m.Action = newActionManager(m)
 	m.Organization = newOrganizationManager(m)
 	m.AttackProtection = newAttackProtectionManager(m)
+	m.DeviceCredential = newDeviceCredentialManager(m)
 
 	return m, nil
 }
@@ -249,28 +611,93 @@ func (m *Management) URI(path ...string) string {
 	return (&url.URL{
 		Scheme: m.url.Scheme,
 		Host:   m.url.Host,
-		Path:   m.basePath + "/" + strings.Join(path, "/"),
+		Path:   m.url.Path + "/" + m.basePath + "/" + strings.Join(path, "/"),
 	}).String()
 }
 
+// payloadTooLarge returns the error reporting that the payload b exceeds the
+// limit, naming its largest top-level field so that it can be acted upon.
+func payloadTooLarge(b []byte, limit int) error {
+	err := fmt.Errorf("request payload of %d bytes exceeds the limit of %d bytes", len(b), limit)
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(b, &fields) != nil {
+		return err
+	}
+	var largest string
+	for k, v := range fields {
+		if len(v) > len(fields[largest]) || (len(v) == len(fields[largest]) && k < largest) {
+			largest = k
+		}
+	}
+	if largest == "" {
+		return err
+	}
+	return fmt.Errorf("%w, the largest field being %q with %d bytes", err, largest, len(fields[largest]))
+}
+
 // NewRequest returns a new HTTP request. If the payload is not nil it will be
-// encoded as JSON.
+// encoded as JSON, unless it is an io.Reader or a []byte, in which case it is
+// sent verbatim. Use ContentType to send such payloads with the content type
+// they are encoded with, e.g. multipart bodies.
 func (m *Management) NewRequest(method, uri string, payload interface{}, options ...RequestOption) (r *http.Request, err error) {
 	var buf bytes.Buffer
 	if payload != nil {
-		err := json.NewEncoder(&buf).Encode(payload)
-		if err != nil {
-			return nil, fmt.Errorf("encoding request payload failed: %w", err)
+		switch p := payload.(type) {
+		case []byte:
+			buf.Write(p)
+		case io.Reader:
+			if _, err := buf.ReadFrom(p); err != nil {
+				return nil, fmt.Errorf("reading request payload failed: %w", err)
+			}
+		default:
+			if err := m.encode(&buf, payload); err != nil {
+				return nil, fmt.Errorf("encoding request payload failed: %w", err)
+			}
+		}
+		if m.maxBodyBytes > 0 && buf.Len() > m.maxBodyBytes {
+			return nil, payloadTooLarge(buf.Bytes(), m.maxBodyBytes)
 		}
 	}
 
-	r, err = http.NewRequest(method, uri, &buf)
+	r, err = http.NewRequestWithContext(m.ctx, method, uri, &buf)
 	if err != nil {
 		return nil, err
 	}
 	r.Header.Add("Content-Type", "application/json")
 
+	if len(m.defaults) == 0 {
+		for _, option := range options {
+			option.apply(r)
+		}
+		return
+	}
+
+	// The default options and the options of the request are applied to the
+	// same query separately, so that every query parameter set by the
+	// options of the request replaces the values set by the defaults. The
+	// page size fallback of list requests is applied last, so that it only
+	// applies if neither set the page size.
+	query := r.URL.RawQuery
+	for _, option := range m.defaults {
+		option.apply(r)
+	}
+	defaults := r.URL.Query()
+
+	r.URL.RawQuery = query
+	var fallbacks []RequestOption
 	for _, option := range options {
+		if _, ok := option.(defaultPerPage); ok {
+			fallbacks = append(fallbacks, option)
+			continue
+		}
+		option.apply(r)
+	}
+	for k, v := range r.URL.Query() {
+		defaults[k] = v
+	}
+	r.URL.RawQuery = defaults.Encode()
+	for _, option := range fallbacks {
 		option.apply(r)
 	}
 
@@ -282,7 +709,13 @@ func (m *Management) NewRequest(method, uri string, payload interface{}, options
 func (m *Management) Do(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
 
+	if m.inspect != nil {
+		m.inspect(cloneRequest(req))
+	}
+
+	start := time.Now()
 	res, err := m.http.Do(req)
+	m.log(req, res, err, time.Since(start))
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -292,33 +725,500 @@ func (m *Management) Do(req *http.Request) (*http.Response, error) {
 		}
 	}
 
+	m.setRateLimit(res.Header)
+
 	return res, nil
 }
 
+// log records the request using the configured logger, redacting secrets.
+func (m *Management) log(req *http.Request, res *http.Response, err error, d time.Duration) {
+	if _, ok := m.logger.(noopLogger); ok {
+		return
+	}
+
+	query := req.URL.Query()
+	for k := range query {
+		if isSecret(k) {
+			query[k] = []string{"[REDACTED]"}
+		}
+	}
+	header := req.Header.Clone()
+	for k := range header {
+		if isSecret(k) || strings.EqualFold(k, "Authorization") {
+			header[k] = []string{"[REDACTED]"}
+		}
+	}
+
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"path":     req.URL.Path,
+		"query":    query,
+		"header":   header,
+		"duration": d,
+	}
+	if res != nil {
+		fields["status"] = res.StatusCode
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	m.logger.Log(req.Context(), fields)
+}
+
+// isSecret reports whether the header or parameter name is likely to hold a
+// secret value.
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "token") || strings.Contains(name, "secret")
+}
+
+// cloneRequest returns a deep copy of r, including a fresh copy of its body
+// when it can be obtained using GetBody.
+func cloneRequest(r *http.Request) *http.Request {
+	c := r.Clone(r.Context())
+	if r.GetBody != nil {
+		if body, err := r.GetBody(); err == nil {
+			c.Body = body
+		}
+	}
+	return c
+}
+
+// RateLimitInfo holds the rate limit information returned by the Auth0
+// Management API.
+//
+// See: https://auth0.com/docs/troubleshoot/customer-support/operational-policies/rate-limit-policy
+type RateLimitInfo struct {
+	// The maximum amount of requests allowed within the current window.
+	Limit int `json:"limit"`
+
+	// The amount of requests remaining in the current window.
+	Remaining int `json:"remaining"`
+
+	// The time at which the current window is reset.
+	Reset time.Time `json:"reset"`
+}
+
+// TokenExpiry returns the expiry of the access token currently used by the
+// management client, or the zero time if it is not known, e.g. because no
+// request has been sent yet or because the token was configured using
+// WithStaticToken.
+//
+// Access tokens obtained using WithClientCredentials are cached and shared by
+// all managers. They are refreshed before they expire, see
+// WithTokenRefreshMargin, as well as when a request fails with a 401 status
+// code, in which case the request is retried once with the new access token.
+// It is safe to call TokenExpiry while requests are in flight in other
+// goroutines.
+func (m *Management) TokenExpiry() time.Time {
+	if s, ok := m.tokenSource.(interface{ Expiry() time.Time }); ok {
+		return s.Expiry()
+	}
+	return time.Time{}
+}
+
+// RateLimit returns the rate limit information of the last response received
+// from the Auth0 Management API which contained rate limit headers.
+//
+// It is safe to call RateLimit while requests are in flight in other
+// goroutines.
+func (m *Management) RateLimit() RateLimitInfo {
+	m.rateLimitMu.RLock()
+	defer m.rateLimitMu.RUnlock()
+	return m.rateLimit
+}
+
+func (m *Management) setRateLimit(h http.Header) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+
+	info := RateLimitInfo{
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		info.Reset = time.Unix(reset, 0)
+	}
+
+	m.rateLimitMu.Lock()
+	defer m.rateLimitMu.Unlock()
+	m.rateLimit = info
+}
+
+// requestContext returns the context requests configured with options would
+// use.
+func (m *Management) requestContext(options []RequestOption) context.Context {
+	r, _ := http.NewRequestWithContext(m.ctx, "GET", "/", nil)
+	for _, option := range options {
+		option.apply(r)
+	}
+	return r.Context()
+}
+
 // Request combines NewRequest and Do, while also handling decoding of response payload.
+//
+// Payloads sent verbatim, i.e. an io.Reader or a []byte, are not used to
+// decode the response payload, which is discarded. Use Call to decode it.
 func (m *Management) Request(method, uri string, v interface{}, options ...RequestOption) error {
-	req, err := m.NewRequest(method, uri, v, options...)
+	switch v.(type) {
+	case io.Reader, []byte:
+		return m.request(method, uri, v, nil, options...)
+	}
+	return m.request(method, uri, v, v, options...)
+}
+
+// Call sends a request to any endpoint of the Auth0 Management API, including
+// those not yet supported by the SDK, using the same authentication, retries,
+// request options and error handling as the rest of the SDK.
+//
+// The path is relative to the Management API, e.g. "roles/rol_123/users". Query
+// parameters are set using options such as Parameter. The body, unless nil, is
+// encoded as JSON, or sent verbatim if it is an io.Reader or a []byte, see
+// ContentType. The response payload is decoded into result, unless it is
+// nil. Errors returned by the API are returned as a *ManagementError.
+func (m *Management) Call(method, path string, body, result interface{}, options ...RequestOption) error {
+	return m.request(method, m.URI(strings.TrimPrefix(path, "/")), body, result, options...)
+}
+
+// ErrAuthentication is matched, using errors.Is, by the error returned by Ping
+// when the credentials of the management client are rejected, either when
+// retrieving an access token or by the Auth0 Management API itself.
+var ErrAuthentication = errors.New("authentication failed")
+
+// Ping verifies the credentials of the management client and the connectivity
+// to the Auth0 Management API, using a lightweight authenticated request. It
+// is useful to fail fast before running long jobs.
+//
+// If the credentials are rejected, the returned error matches
+// ErrAuthentication and wraps the underlying error, e.g. a *ManagementError.
+// Other errors, such as network errors, are returned as is.
+func (m *Management) Ping(ctx context.Context) error {
+	err := m.request("GET", m.URI("tenants", "settings"), nil, nil, Context(ctx), IncludeFields("friendly_name"))
+	if err == nil {
+		return nil
+	}
+
+	var status int
+	var merr *ManagementError
+	var rerr *oauth2.RetrieveError
+	switch {
+	case errors.As(err, &merr):
+		status = merr.StatusCode
+	case errors.As(err, &rerr) && rerr.Response != nil:
+		status = rerr.Response.StatusCode
+	}
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return &authenticationError{err}
+	}
+	return err
+}
+
+// authenticationError wraps errors caused by rejected credentials.
+type authenticationError struct {
+	err error
+}
+
+func (e *authenticationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrAuthentication, e.err)
+}
+
+func (e *authenticationError) Unwrap() error { return e.err }
+
+func (e *authenticationError) Is(target error) bool { return target == ErrAuthentication }
+
+// request sends payload, unless nil, to uri and decodes the response payload
+// into result, unless nil.
+func (m *Management) request(method, uri string, payload, result interface{}, options ...RequestOption) (err error) {
+	req, err := m.NewRequest(method, uri, payload, options...)
 	if err != nil {
 		return err
 	}
+	req, cancel := m.withTimeout(req, options)
+	defer cancel()
+
+	var res *http.Response
+	if m.tracer != nil {
+		var span Span
+		req, span = m.startSpan(req)
+		defer func() {
+			if res != nil {
+				span.SetAttribute("http.status_code", res.StatusCode)
+			}
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}()
+	}
 
-	res, err := m.Do(req)
+	res, err = m.Do(req)
+	if errors.Is(err, ErrDryRun) {
+		return ErrDryRun
+	}
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
+	if err := decompress(res); err != nil {
+		res.Body.Close()
+		return fmt.Errorf("decompressing response payload failed: %w", err)
+	}
+	defer func() {
+		// The body is drained and closed before the response is passed to
+		// options such as CaptureResponse, so that the connection can be
+		// reused independently of what the options do with it.
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		_ = res.Body.Close()
+
+		for _, option := range options {
+			if o, ok := option.(responseOption); ok {
+				o.applyResponse(res)
+			}
+		}
+	}()
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return newError(res.Body)
+		return newError(res)
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	// Accepted requests, such as user imports, usually but not always respond
+	// with a payload.
+	body := bufio.NewReader(res.Body)
+	if _, err := body.Peek(1); err == io.EOF && res.StatusCode == http.StatusAccepted {
+		return nil
+	}
+	if err := m.decode(body, result); err != nil {
+		return fmt.Errorf("decoding response payload failed: %w", err)
+	}
+
+	return nil
+}
+
+// startSpan starts the span tracing req, returning a copy of req using the
+// context holding the span and propagating it.
+func (m *Management) startSpan(req *http.Request) (*http.Request, Span) {
+	resource := strings.TrimPrefix(req.URL.Path, m.url.Path)
+	resource = strings.TrimPrefix(strings.TrimPrefix(resource, "/"), m.basePath+"/")
+	if i := strings.Index(resource, "/"); i != -1 {
+		resource = resource[:i]
+	}
+
+	ctx, span := m.tracer.StartSpan(req.Context(), req.Method+" "+resource)
+	req = req.WithContext(ctx)
+	m.tracer.Inject(ctx, req.Header)
+
+	u := *req.URL
+	u.RawQuery = ""
+	u.User = nil
+	span.SetAttribute("http.method", req.Method)
+	span.SetAttribute("http.url", u.String())
+
+	return req, span
+}
+
+// decompress replaces the body of res with its decompressed payload if it is
+// gzip encoded. This is only needed when the "Accept-Encoding" header was set
+// explicitly, as the payload is otherwise decompressed by the transport.
+func decompress(res *http.Response) error {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+	gr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return err
+	}
+	res.Body = &gzipBody{gr, res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	return nil
+}
+
+// gzipBody reads the decompressed payload of a response body, closing both
+// when it is closed.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// encode writes the JSON encoding of v to w, using the configured Codec if
+// any. Values implementing json.Marshaler are always encoded using their own
+// MarshalJSON method.
+func (m *Management) encode(w io.Writer, v interface{}) error {
+	if m.codec == nil {
+		return json.NewEncoder(w).Encode(v)
+	}
+	var b []byte
+	var err error
+	if jm, ok := v.(json.Marshaler); ok {
+		b, err = jm.MarshalJSON()
+	} else {
+		b, err = m.codec.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// decode reads the JSON encoded value from r and stores it in v, using the
+// configured Codec if any. Values implementing json.Unmarshaler are always
+// decoded using their own UnmarshalJSON method.
+func (m *Management) decode(r io.Reader, v interface{}) error {
+	if m.codec == nil {
+		return json.NewDecoder(r).Decode(v)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if ju, ok := v.(json.Unmarshaler); ok {
+		return ju.UnmarshalJSON(b)
+	}
+	return m.codec.Unmarshal(b, v)
+}
+
+// marshalWithExtra serializes v, adding any extra properties which are not
+// already set by v. It is used by types which preserve properties not modeled
+// by the SDK, such as log stream sinks.
+func marshalWithExtra(v interface{}, extra map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
 	}
 
-	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusAccepted {
-		err := json.NewDecoder(res.Body).Decode(v)
+	return json.Marshal(m)
+}
+
+// unmarshalWithExtra deserializes b into the struct pointed to by v, returning
+// the properties which are not known to v.
+func unmarshalWithExtra(b []byte, v interface{}) (map[string]interface{}, error) {
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		delete(m, name)
+	}
+
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	return m, nil
+}
+
+// stream sends a GET request to uri and decodes the response payload one
+// element at a time, calling decode for each element of the array found under
+// key. If key is empty the response payload is expected to be an array.
+//
+// It returns the amount of elements decoded. Any error returned by decode
+// stops the decoding and is returned as is.
+func (m *Management) stream(uri, key string, decode func(dec *json.Decoder) error, options ...RequestOption) (int, error) {
+	req, err := m.NewRequest("GET", uri, nil, options...)
+	if err != nil {
+		return 0, err
+	}
+	req, cancel := m.withTimeout(req, options)
+	defer cancel()
+
+	res, err := m.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	if err := decompress(res); err != nil {
+		res.Body.Close()
+		return 0, fmt.Errorf("decompressing response payload failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		return 0, newError(res)
+	}
+
+	dec := json.NewDecoder(res.Body)
+
+	if key != "" {
+		found, err := seekKey(dec, key)
+		if err != nil || !found {
+			return 0, err
+		}
+	}
+
+	if err := expectDelim(dec, '['); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for dec.More() {
+		if err := decode(dec); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// seekKey advances dec, which must be positioned at the start of an object,
+// until the value of key. It returns false if the object does not contain key.
+func seekKey(dec *json.Decoder, key string) (bool, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return false, err
+	}
+	for dec.More() {
+		t, err := dec.Token()
 		if err != nil {
-			return fmt.Errorf("decoding response payload failed: %w", err)
+			return false, fmt.Errorf("decoding response payload failed: %w", err)
+		}
+		if t == key {
+			return true, nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return false, fmt.Errorf("decoding response payload failed: %w", err)
 		}
-		return res.Body.Close()
 	}
+	return false, nil
+}
 
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decoding response payload failed: %w", err)
+	}
+	if t != delim {
+		return fmt.Errorf("decoding response payload failed: expected %q but got %v", delim, t)
+	}
 	return nil
 }
 
@@ -331,31 +1231,199 @@ type Error interface {
 	error
 }
 
-type managementError struct {
-	StatusCode int    `json:"statusCode"`
-	Err        string `json:"error"`
-	Message    string `json:"message"`
+// ManagementError is the error returned by the Auth0 Management API when a
+// request fails. It implements the Error interface, and can be retrieved from
+// any error returned by the managers using errors.As.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Errors
+type ManagementError struct {
+	// The HTTP status code of the response.
+	StatusCode int `json:"statusCode"`
+
+	// The description of the HTTP status code, e.g. "Not Found".
+	Err string `json:"error"`
+
+	// A human readable description of the error.
+	Message string `json:"message"`
+
+	// The Auth0 specific error code, e.g. "inexistent_user". Not every error
+	// carries an error code.
+	ErrorCode string `json:"errorCode,omitempty"`
 }
 
-func newError(r io.Reader) error {
-	m := &managementError{}
-	err := json.NewDecoder(r).Decode(m)
-	if err != nil {
-		return err
+func newError(res *http.Response) error {
+	m := &ManagementError{}
+	if err := json.NewDecoder(res.Body).Decode(m); err != nil {
+		// The response payload is not a JSON error, e.g. when the error
+		// is returned by a proxy.
+		m = &ManagementError{}
+	}
+	if m.StatusCode == 0 {
+		m.StatusCode = res.StatusCode
+	}
+	if m.Err == "" {
+		m.Err = http.StatusText(res.StatusCode)
 	}
 	return m
 }
 
 // Error formats the error into a string representation.
-func (m *managementError) Error() string {
+func (m *ManagementError) Error() string {
+	if scopes := m.RequiredScopes(); len(scopes) > 0 {
+		return fmt.Sprintf("%d %s: this call requires %s", m.StatusCode, m.Err, strings.Join(scopes, " or "))
+	}
+	if m.StatusCode == http.StatusUnauthorized {
+		return fmt.Sprintf("%d %s: access token rejected or expired: %s", m.StatusCode, m.Err, m.Message)
+	}
 	return fmt.Sprintf("%d %s: %s", m.StatusCode, m.Err, m.Message)
 }
 
+// insufficientScope is the message prefix of the errors caused by an access
+// token missing the scopes required by a request, e.g. "Insufficient scope,
+// expected any of: update:log_streams".
+const insufficientScope = "Insufficient scope, expected any of: "
+
+// RequiredScopes returns the scopes the request which failed requires, any of
+// which is sufficient, if the error was caused by the access token missing
+// them. It returns nil otherwise.
+func (m *ManagementError) RequiredScopes() []string {
+	if m.StatusCode != http.StatusForbidden || !strings.HasPrefix(m.Message, insufficientScope) {
+		return nil
+	}
+	var scopes []string
+	for _, s := range strings.Split(strings.TrimPrefix(m.Message, insufficientScope), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
 // Status returns the status code of the error.
-func (m *managementError) Status() int {
+func (m *ManagementError) Status() int {
 	return m.StatusCode
 }
 
+// AsManagementError returns the ManagementError err wraps, if any. It is
+// useful to branch on the status code or the Auth0 error code of a failed
+// request.
+//
+// For example:
+//   err := m.Client.Create(c)
+//   if mErr, ok := AsManagementError(err); ok && mErr.StatusCode == http.StatusConflict {
+//       // the client already exists
+//   }
+func AsManagementError(err error) (*ManagementError, bool) {
+	var mErr *ManagementError
+	if errors.As(err, &mErr) {
+		return mErr, true
+	}
+	return nil, false
+}
+
+// IsNotFound reports whether err was caused by a resource which doesn't
+// exist, i.e. a 404 status code.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err was caused by a conflict with an existing
+// resource, i.e. a 409 status code.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed reports whether err was caused by a failed
+// precondition, i.e. a 412 status code. This is typically the case when the
+// resource was modified since it was read, see IfMatch and IfUnmodifiedSince.
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsInsufficientScope reports whether err was caused by the access token
+// missing the scopes required by the request, which are returned by
+// ManagementError.RequiredScopes.
+func IsInsufficientScope(err error) bool {
+	mErr, ok := AsManagementError(err)
+	return ok && len(mErr.RequiredScopes()) > 0
+}
+
+func hasStatus(err error, status int) bool {
+	var mErr Error
+	return errors.As(err, &mErr) && mErr.Status() == status
+}
+
+// multiError holds the errors of operations which are carried out
+// independently of each other, such as the deletes of
+// LogStreamManager.DeleteAll.
+type multiError []error
+
+// forEachConcurrently calls fn for each index below n, with at most the
+// concurrency configured by opts in parallel, see WithConcurrency. A failure
+// does not prevent the remaining calls, and the returned error joins every
+// failure, which can be inspected using errors.Is and errors.As.
+func forEachConcurrently(n int, opts []RequestOption, fn func(i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, concurrencyOf(opts))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return joinErrors(errs)
+}
+
+// joinErrors returns the non-nil errors in errs as a single error, or nil if
+// there are none.
+func joinErrors(errs []error) error {
+	var me multiError
+	for _, err := range errs {
+		if err != nil {
+			me = append(me, err)
+		}
+	}
+	if len(me) == 0 {
+		return nil
+	}
+	return me
+}
+
+func (me multiError) Error() string {
+	msgs := make([]string, len(me))
+	for i, err := range me {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether any of the joined errors matches target, so that they
+// can be inspected using errors.Is.
+func (me multiError) Is(target error) bool {
+	for _, err := range me {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the joined errors which matches target, so that they
+// can be inspected using errors.As.
+func (me multiError) As(target interface{}) bool {
+	for _, err := range me {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // List is an envelope which is typically used when calling List() or Search()
 // methods.
 //
@@ -375,6 +1443,103 @@ func (l List) HasNext() bool {
 	return l.Total > l.Start+l.Limit
 }
 
+// count requests the total amount of items listed by uri, without retrieving
+// any of them.
+func (m *Management) count(uri string, opts []RequestOption) (int, error) {
+	var l List
+	err := m.Request("GET", uri, &l, append(opts, PerPage(0), IncludeTotals(true))...)
+	return l.Total, err
+}
+
+// ErrIteratorDone is returned by the Next method of list iterators once all
+// items have been returned.
+var ErrIteratorDone = errors.New("no more items in iterator")
+
+// Pager requests successive pages of a list endpoint, making it possible to
+// walk through the results of any List method without keeping track of the
+// page number.
+//
+// Pages are requested one at a time, starting from the page configured in the
+// request options (or the first page), until a page returns fewer items than
+// the configured page size.
+//
+// For example:
+//   var users []*User
+//   p := NewPager(func(opts ...RequestOption) (int, error) {
+//       l, err := m.User.List(opts...)
+//       if err != nil {
+//           return 0, err
+//       }
+//       users = append(users, l.Users...)
+//       return len(l.Users), nil
+//   })
+//   for p.Next() {
+//       // users holds the items of all pages requested so far
+//   }
+//   if err := p.Err(); err != nil {
+//       // handle err
+//   }
+type Pager struct {
+	fetch   func(opts ...RequestOption) (int, error)
+	opts    []RequestOption
+	page    int
+	perPage int
+	last    bool
+	err     error
+}
+
+// NewPager returns a Pager which requests pages using fetch. The fetch
+// function is called with the provided request options followed by a Page
+// option, and must return the amount of items contained in the page.
+func NewPager(fetch func(opts ...RequestOption) (int, error), opts ...RequestOption) *Pager {
+	r, _ := http.NewRequest("GET", "/", nil)
+	for _, option := range applyListDefaults(opts) {
+		option.apply(r)
+	}
+
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+
+	return &Pager{fetch: fetch, opts: opts, page: page, perPage: perPage}
+}
+
+// Next requests the next page. It returns false if there are no more items to
+// be retrieved or the request failed, in which case Err returns the error.
+func (p *Pager) Next() bool {
+	if p.last || p.err != nil {
+		return false
+	}
+
+	opts := append(append([]RequestOption{}, p.opts...), Page(p.page))
+
+	n, err := p.fetch(opts...)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.page++
+	if n < p.perPage {
+		p.last = true
+	}
+
+	return n > 0
+}
+
+// Err returns the error of the failed request, if any.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// done returns the error which caused the pager to stop.
+func (p *Pager) done() error {
+	if p.err != nil {
+		return p.err
+	}
+	return ErrIteratorDone
+}
+
 // RequestOption configures a call (typically to retrieve a resource) to Auth0 with
 // query parameters.
 type RequestOption interface {
@@ -393,23 +1558,74 @@ func (o *requestOption) apply(r *http.Request) {
 	o.applyFn(r)
 }
 
-func applyListDefaults(options []RequestOption) RequestOption {
-	return newRequestOption(func(r *http.Request) {
-		PerPage(50).apply(r)
-		for _, option := range options {
-			option.apply(r)
-		}
-		IncludeTotals(true).apply(r)
-	})
+// applyListDefaults returns the options of list requests, which retrieve 50
+// items per page unless configured otherwise by options or by the default
+// options of the client, and always include totals.
+//
+// The options are returned as is, rather than combined into a single option,
+// so that typed options such as WithTimeout or CaptureResponse still apply.
+func applyListDefaults(options []RequestOption) []RequestOption {
+	opts := make([]RequestOption, 0, len(options)+2)
+	opts = append(opts, defaultPerPage(50))
+	opts = append(opts, options...)
+	return append(opts, IncludeTotals(true))
+}
+
+// defaultPerPage is a RequestOption which limits the amount of items in the
+// result unless the limit is already set. NewRequest applies it after the
+// default options of the client, see WithDefaultOptions.
+type defaultPerPage int
+
+func (d defaultPerPage) apply(r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("per_page") != "" {
+		return
+	}
+	q.Set("per_page", strconv.Itoa(int(d)))
+	r.URL.RawQuery = q.Encode()
 }
 
 // Context configures a request to use the specified context.
+//
+// Cancelling the context aborts the request, including any pending retries,
+// in which case the error returned wraps ctx.Err().
 func Context(ctx context.Context) RequestOption {
 	return newRequestOption(func(r *http.Request) {
 		*r = *r.WithContext(ctx)
 	})
 }
 
+// WithTimeout configures a request to be aborted if it does not complete
+// within d, including any pending retries, in which case the error returned
+// wraps context.DeadlineExceeded.
+//
+// The timeout applies on top of the context of the request, see Context and
+// WithContext, so the earliest of both deadlines is honored.
+func WithTimeout(d time.Duration) RequestOption {
+	return timeout(d)
+}
+
+// timeout is a RequestOption which is applied by Management.Request, as the
+// context it derives must be cancelled once the response has been read.
+type timeout time.Duration
+
+func (timeout) apply(*http.Request) {}
+
+// withTimeout returns req with a context honoring the timeout configured by
+// options, or else by the default options, if any, along with the function
+// releasing that context.
+func (m *Management) withTimeout(req *http.Request, options []RequestOption) (*http.Request, context.CancelFunc) {
+	for _, options := range [][]RequestOption{options, m.defaults} {
+		for i := len(options) - 1; i >= 0; i-- {
+			if d, ok := options[i].(timeout); ok {
+				ctx, cancel := context.WithTimeout(req.Context(), time.Duration(d))
+				return req.WithContext(ctx), cancel
+			}
+		}
+	}
+	return req, func() {}
+}
+
 // IncludeFields configures a request to include the desired fields.
 func IncludeFields(fields ...string) RequestOption {
 	return newRequestOption(func(r *http.Request) {
@@ -430,8 +1646,52 @@ func ExcludeFields(fields ...string) RequestOption {
 	})
 }
 
+// Fields configures a request to only send the named fields of its payload,
+// dropping any other field from the serialized JSON body. Field names are
+// the JSON names, e.g. "status" rather than "Status".
+//
+// This is mostly useful for PATCH requests, to guarantee only the intended
+// attributes are updated regardless of what else is set on the payload:
+//
+//   m.LogStream.Update(id, l, management.Fields("status"))
+//
+// Unlike IncludeFields, which selects the fields returned by Auth0, Fields
+// only affects the request payload. Payloads which are not JSON objects are
+// sent unchanged.
+func Fields(fields ...string) RequestOption {
+	return newRequestOption(func(r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		defer func() { Body(b).apply(r) }()
+
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal(b, &payload); err != nil {
+			return
+		}
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if v, ok := payload[field]; ok {
+				selected[field] = v
+			}
+		}
+		if sb, err := json.Marshal(selected); err == nil {
+			b = sb
+		}
+	})
+}
+
 // Page configures a request to receive a specific page, if the results where
 // concatenated.
+//
+// Offset pagination is supported by most managers that return lists, such as
+// the UserManager, ClientManager, ConnectionManager or RoleManager. The
+// LogManager only supports offset pagination for the first 1000 records, use
+// From and Take to retrieve logs beyond those.
 func Page(page int) RequestOption {
 	return newRequestOption(func(r *http.Request) {
 		q := r.URL.Query()
@@ -440,6 +1700,22 @@ func Page(page int) RequestOption {
 	})
 }
 
+// Sort configures a request to sort the results by field, in ascending or
+// descending order, e.g. Sort("date", false) to retrieve the most recent logs
+// first.
+//
+// Sorting is supported by the users and logs endpoints, used by UserManager
+// and LogManager. It can be passed to the List methods of other managers, such
+// as RoleManager and ConnectionManager, but endpoints which do not support
+// sorting silently ignore it.
+func Sort(field string, ascending bool) RequestOption {
+	order := "-1"
+	if ascending {
+		order = "1"
+	}
+	return Parameter("sort", field+":"+order)
+}
+
 // PerPage configures a request to limit the amount of items in the result.
 func PerPage(items int) RequestOption {
 	return newRequestOption(func(r *http.Request) {
@@ -449,6 +1725,61 @@ func PerPage(items int) RequestOption {
 	})
 }
 
+// From configures a request to retrieve log entries starting from the log
+// entry identified by id, also known as checkpoint pagination.
+//
+// Checkpoint pagination is supported by the logs endpoint, used by
+// LogManager, as well as by the organizations and organization members
+// endpoints, used by OrganizationManager.List and OrganizationManager.Members.
+// It can not be combined with Page and PerPage. See LogManager.ListCheckpoint
+// and LogManager.ListAll for a convenient way of paginating through log
+// entries.
+func From(id string) RequestOption {
+	return newRequestOption(func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("from", id)
+		r.URL.RawQuery = q.Encode()
+	})
+}
+
+// Take configures a request to limit the amount of log entries retrieved when
+// using checkpoint pagination.
+func Take(items int) RequestOption {
+	return newRequestOption(func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("take", strconv.FormatInt(int64(items), 10))
+		r.URL.RawQuery = q.Encode()
+	})
+}
+
+// WithConcurrency configures methods which issue several requests, such as
+// LogStreamManager.DeleteAll, to issue at most n of them in parallel. It has
+// no effect on any other method. Rate limited requests are retried as usual,
+// see WithRetries.
+func WithConcurrency(n int) RequestOption {
+	if n < 1 {
+		n = 1
+	}
+	return concurrency(n)
+}
+
+// concurrency is a RequestOption which leaves the request itself untouched.
+type concurrency int
+
+func (concurrency) apply(*http.Request) {}
+
+// concurrencyOf returns the concurrency configured by options, defaulting to
+// requests being issued one at a time.
+func concurrencyOf(options []RequestOption) int {
+	n := 1
+	for _, option := range options {
+		if c, ok := option.(concurrency); ok {
+			n = int(c)
+		}
+	}
+	return n
+}
+
 // IncludeTotals configures a request to include totals.
 func IncludeTotals(include bool) RequestOption {
 	return newRequestOption(func(r *http.Request) {
@@ -476,8 +1807,59 @@ func Query(s string) RequestOption {
 	})
 }
 
+// QueryPhrase quotes s so that it can be used as a value in a search query,
+// escaping any character which would otherwise change the meaning of the
+// query, such as quotes or colons.
+//
+// For example:
+//   List(Query("email:" + QueryPhrase(email)))
+func QueryPhrase(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// QueryEquals returns a search query term matching field against the phrase
+// value, e.g. `email:"alice@example.com"`.
+func QueryEquals(field, value string) string {
+	return field + ":" + QueryPhrase(value)
+}
+
+// QueryExists returns a search query term matching the resources for which
+// field is set, e.g. `_exists_:app_metadata.plan`.
+func QueryExists(field string) string {
+	return "_exists_:" + field
+}
+
+// QueryAnd returns a search query matching all of the given terms.
+//
+// For example:
+//   List(Query(QueryAnd(
+//       QueryEquals("email", "alice@example.com"),
+//       QueryExists("app_metadata.plan"),
+//   )))
+func QueryAnd(terms ...string) string {
+	return joinQuery(" AND ", terms)
+}
+
+// QueryOr returns a search query matching any of the given terms.
+func QueryOr(terms ...string) string {
+	return joinQuery(" OR ", terms)
+}
+
+func joinQuery(op string, terms []string) string {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	grouped := make([]string, len(terms))
+	for i, t := range terms {
+		grouped[i] = "(" + t + ")"
+	}
+	return strings.Join(grouped, op)
+}
+
 // Parameter configures a request to add arbitrary query parameters to requests
-// made to Auth0.
+// made to Auth0. Any value of the parameter set by a previous option is
+// replaced, use Parameters to add values instead.
 func Parameter(key, value string) RequestOption {
 	return newRequestOption(func(r *http.Request) {
 		q := r.URL.Query()
@@ -486,17 +1868,152 @@ func Parameter(key, value string) RequestOption {
 	})
 }
 
+// Parameters configures a request to add arbitrary query parameters to
+// requests made to Auth0. The values are added to those set by other options,
+// so parameters which are not yet supported by a dedicated option can be used
+// alongside options such as IncludeFields.
+func Parameters(values url.Values) RequestOption {
+	return newRequestOption(func(r *http.Request) {
+		q := r.URL.Query()
+		for key, vs := range values {
+			for _, v := range vs {
+				q.Add(key, v)
+			}
+		}
+		r.URL.RawQuery = q.Encode()
+	})
+}
+
+// responseOption is implemented by request options which need to inspect
+// the response of a request. The body of the response has already been read
+// and closed when applyResponse is called.
+type responseOption interface {
+	RequestOption
+	applyResponse(*http.Response)
+}
+
+// ETag configures a request to store the "ETag" header of its response in
+// etag, if the request succeeds. Pass it to IfMatch in order to only update a
+// resource if it was not modified since it was read:
+//
+//   var etag string
+//   l, err := m.LogStream.Read(id, management.ETag(&etag))
+//   // ...
+//   err = m.LogStream.Update(id, l, management.IfMatch(etag))
+//   if management.IsPreconditionFailed(err) {
+//       // the log stream was modified, read it again and retry
+//   }
+//
+// The etag is left empty if the response has no "ETag" header.
+func ETag(etag *string) RequestOption {
+	return &etagOption{etag}
+}
+
+type etagOption struct {
+	etag *string
+}
+
+func (o *etagOption) apply(*http.Request) {}
+
+func (o *etagOption) applyResponse(r *http.Response) {
+	if r.StatusCode < http.StatusBadRequest {
+		*o.etag = r.Header.Get("ETag")
+	}
+}
+
+// CaptureResponse configures a request to store its HTTP response in res,
+// including when the request fails with an error status code. This gives
+// access to the status code, headers and trailers which are otherwise
+// discarded.
+//
+// As the SDK reads and closes the body of the response, the captured
+// response's Body is always empty.
+func CaptureResponse(res **http.Response) RequestOption {
+	return &captureResponseOption{res}
+}
+
+type captureResponseOption struct {
+	res **http.Response
+}
+
+func (o *captureResponseOption) apply(*http.Request) {}
+
+func (o *captureResponseOption) applyResponse(r *http.Response) {
+	c := *r
+	c.Body = http.NoBody
+	*o.res = &c
+}
+
+// IfMatch configures a request to send the "If-Match" header. The request
+// fails with a 412 status code if the resource no longer matches etag, which
+// can be checked using IsPreconditionFailed.
+func IfMatch(etag string) RequestOption {
+	return Header("If-Match", etag)
+}
+
+// IfUnmodifiedSince configures a request to send the "If-Unmodified-Since"
+// header, for resources which do not support ETags. The request fails with a
+// 412 status code if the resource was modified after t, which can be checked
+// using IsPreconditionFailed.
+//
+// Note that the header has a precision of one second.
+func IfUnmodifiedSince(t time.Time) RequestOption {
+	return Header("If-Unmodified-Since", t.UTC().Format(http.TimeFormat))
+}
+
+// ContentType configures a request to send its payload with the given content
+// type instead of "application/json". It is meant to be used with payloads
+// which are sent verbatim, i.e. an io.Reader or a []byte, such as the
+// multipart or form encoded bodies expected by some endpoints.
+func ContentType(contentType string) RequestOption {
+	return Header("Content-Type", contentType)
+}
+
 // Header configures a request to add HTTP headers to requests made to Auth0.
+//
+// Using Header several times adds every header, a header being replaced only
+// if it is set again. The "Content-Type" header may be replaced this way, but
+// the "Authorization" header is always set using the access token.
 func Header(key, value string) RequestOption {
 	return newRequestOption(func(r *http.Request) {
 		r.Header.Set(key, value)
 	})
 }
 
+// GzipBody configures a request to compress its payload using gzip, setting
+// the "Content-Encoding" header accordingly. This reduces the amount of data
+// sent for large payloads.
+//
+// Responses are decompressed transparently, regardless of this option.
+func GzipBody() RequestOption {
+	return newRequestOption(func(r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			return
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err := gw.Write(b); err != nil || gw.Close() != nil {
+			Body(b).apply(r)
+			return
+		}
+		Body(buf.Bytes()).apply(r)
+		r.Header.Set("Content-Encoding", "gzip")
+	})
+}
+
 // Body configures a requests body.
 func Body(b []byte) RequestOption {
 	return newRequestOption(func(r *http.Request) {
 		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+		r.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(b)), nil
+		}
+		r.ContentLength = int64(len(b))
 	})
 }
 
@@ -1,16 +1,27 @@
 package management
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
+	"golang.org/x/oauth2"
 
+	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
@@ -70,9 +81,100 @@ func TestOptionFields(t *testing.T) {
 
 	ExcludeFields("foo", "bar").apply(r)
 
+	v = r.URL.Query()
+
 	includeFields = v.Get("include_fields")
-	if includeFields != "true" {
-		t.Errorf("Expected %q, but got %q", includeFields, "true")
+	if includeFields != "false" {
+		t.Errorf("Expected %q, but got %q", includeFields, "false")
+	}
+}
+
+func TestOptionPayloadFields(t *testing.T) {
+	m, err := New("example.com", WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &LogStream{
+		ID:     auth0.String("lst_123"),
+		Name:   auth0.String("foo"),
+		Status: auth0.String(LogStreamStatusPaused),
+	}
+	r, err := m.NewRequest("PATCH", "/", l, Fields("status", "sink"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadAll(r.Body)
+	expect.Expect(t, string(b), `{"status":"paused"}`)
+	expect.Expect(t, r.ContentLength, int64(len(b)))
+
+	r, _ = m.NewRequest("PATCH", "/", []string{"foo"}, Fields("status"))
+	b, _ = ioutil.ReadAll(r.Body)
+	expect.Expect(t, string(b), `["foo"]`+"\n")
+
+	r, _ = m.NewRequest("GET", "/", nil, Fields("status"))
+	expect.Expect(t, r.Body, http.NoBody)
+}
+
+func TestOptionHeader(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Header.Get("X-Correlation-ID"), "123")
+		expect.Expect(t, r.Header.Get("Auth0-Client"), "proxy")
+		expect.Expect(t, r.Header.Get("Content-Type"), "application/json")
+		expect.Expect(t, r.Header.Get("Authorization"), "Bearer token")
+		w.Write([]byte(`{"id":"rol_123"}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithStaticToken("token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Role.Read("rol_123",
+		Header("X-Correlation-ID", "123"),
+		Header("Auth0-Client", "proxy"),
+		Header("Authorization", "Bearer other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOptionParameters(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	IncludeFields("id", "name").apply(r)
+	Parameter("q", "foo").apply(r)
+	Parameters(url.Values{
+		"q":    {"bar"},
+		"sort": {"name:1"},
+	}).apply(r)
+
+	v := r.URL.Query()
+	expect.Expect(t, v.Get("fields"), "id,name")
+	expect.Expect(t, v.Get("include_fields"), "true")
+	expect.Expect(t, v["q"], []string{"foo", "bar"})
+	expect.Expect(t, v.Get("sort"), "name:1")
+
+	Parameter("q", "baz").apply(r)
+	expect.Expect(t, r.URL.Query()["q"], []string{"baz"})
+}
+
+func TestQueryHelpers(t *testing.T) {
+	for _, test := range []struct {
+		have, want string
+	}{
+		{QueryPhrase("jane smith"), `"jane smith"`},
+		{QueryPhrase(`a"b\c:d`), `"a\"b\\c:d"`},
+		{QueryEquals("email", "alice@example.com"), `email:"alice@example.com"`},
+		{QueryExists("app_metadata.plan"), `_exists_:app_metadata.plan`},
+		{QueryAnd(QueryEquals("name", "jane")), `name:"jane"`},
+		{
+			QueryAnd(QueryEquals("email", "a@b.com"), QueryOr(QueryExists("app_metadata.plan"), QueryExists("app_metadata.trial"))),
+			`(email:"a@b.com") AND ((_exists_:app_metadata.plan) OR (_exists_:app_metadata.trial))`,
+		},
+	} {
+		expect.Expect(t, test.have, test.want)
 	}
 }
 
@@ -95,6 +197,25 @@ func TestOptionPage(t *testing.T) {
 	}
 }
 
+func TestOptionCheckpoint(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	From("90020220301").apply(r)
+	Take(25).apply(r)
+
+	v := r.URL.Query()
+
+	from := v.Get("from")
+	if from != "90020220301" {
+		t.Errorf("Expected %q, but got %q", "90020220301", from)
+	}
+
+	take := v.Get("take")
+	if take != "25" {
+		t.Errorf("Expected %q, but got %q", "25", take)
+	}
+}
+
 func TestOptionTotals(t *testing.T) {
 	r, _ := http.NewRequest("GET", "/", nil)
 
@@ -130,10 +251,12 @@ func TestOptionParameter(t *testing.T) {
 func TestOptionDefauls(t *testing.T) {
 	r, _ := http.NewRequest("GET", "/", nil)
 
-	applyListDefaults([]RequestOption{
+	for _, option := range applyListDefaults([]RequestOption{
 		PerPage(20),          // should be persist (default is 50)
 		IncludeTotals(false), // should be altered to true by withListDefaults
-	}).apply(r)
+	}) {
+		option.apply(r)
+	}
 
 	v := r.URL.Query()
 
@@ -188,6 +311,147 @@ func TestRequestOptionContextTimeout(t *testing.T) {
 	}
 }
 
+func TestRequestOptionWithTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	err = m.Request("GET", m.URI("slow"), nil, WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+
+	// The shorter deadline of the context takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = m.Request("GET", m.URI("slow"), nil, Context(ctx), WithTimeout(time.Hour))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+
+	// The timeout also applies to list requests, whose options are combined
+	// with the list defaults.
+	_, err = m.Role.List(WithTimeout(10 * time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+	err = m.User.ListStream(func(*User) error { return nil }, WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the requests to time out, took %s", elapsed)
+	}
+
+	var v map[string]interface{}
+	err = m.Request("GET", m.URI("slow"), &v, WithTimeout(5*time.Second))
+	if err != nil {
+		t.Errorf("expected the request to succeed, got %v", err)
+	}
+}
+
+func TestManagementCall(t *testing.T) {
+	var method, uri, body string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		method, uri, body = r.Method, r.URL.RequestURI(), string(b)
+		if r.URL.Path == "/api/v2/widgets/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Widget not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"wdg_123","name":"example"}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result map[string]interface{}
+	err = m.Call("POST", "/widgets", map[string]string{"name": "example"}, &result, Parameter("dry_run", "true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, method, "POST")
+	expect.Expect(t, uri, "/api/v2/widgets?dry_run=true")
+	expect.Expect(t, body, `{"name":"example"}`+"\n")
+	expect.Expect(t, result, map[string]interface{}{"id": "wdg_123", "name": "example"})
+
+	err = m.Call("DELETE", "widgets/wdg_123", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, body, "")
+
+	err = m.Call("GET", "widgets/missing", nil, &result)
+	if mErr, ok := err.(*ManagementError); !ok || mErr.Status() != http.StatusNotFound {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestNew_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // cancel every request made by the client
+
+	m, err := New("example.com", WithInsecure(), WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Request("GET", m.URI("users", "123"), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected err to be context.Canceled, got %v", err)
+	}
+}
+
+func TestRequestContextInFlight(t *testing.T) {
+	unblock := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock: // block until the test is done
+		case <-time.After(5 * time.Second):
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+	defer close(unblock)
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = m.User.Read("123", Context(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected err to be context.Canceled, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = m.User.Read("123", Context(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+}
+
 func TestNew_WithInsecure(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -211,3 +475,1308 @@ func TestNew_WithInsecure(t *testing.T) {
 
 	expect.Expect(t, u.GetID(), "123")
 }
+
+func TestListIterator(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Query().Get("per_page"), "2")
+		switch r.URL.Query().Get("page") {
+		case "0":
+			w.Write([]byte(`{"start":0,"limit":2,"total":3,"users":[{"user_id":"1"},{"user_id":"2"}]}`))
+		case "1":
+			w.Write([]byte(`{"start":2,"limit":2,"total":3,"users":[{"user_id":"3"}]}`))
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+			w.Write([]byte(`{"users":[]}`))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	it := m.User.ListAll(PerPage(2))
+	for {
+		u, err := it.Next()
+		if err == ErrIteratorDone {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, u.GetID())
+	}
+
+	expect.Expect(t, ids, []string{"1", "2", "3"})
+}
+
+func TestListIteratorError(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Insufficient scope"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Role.ListAll().Next()
+	if err == nil || err == ErrIteratorDone {
+		t.Fatalf("expected a request error, got %v", err)
+	}
+}
+
+func TestPager(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"start":10,"limit":10,"total":12,"clients":[{"client_id":"1"},{"client_id":"2"}]}`))
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+			w.Write([]byte(`{"clients":[]}`))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var clients []*Client
+	p := NewPager(func(opts ...RequestOption) (int, error) {
+		l, err := m.Client.List(opts...)
+		if err != nil {
+			return 0, err
+		}
+		clients = append(clients, l.Clients...)
+		return len(l.Clients), nil
+	}, Page(1), PerPage(10))
+
+	pages := 0
+	for p.Next() {
+		pages++
+	}
+
+	expect.Expect(t, p.Err(), nil)
+	expect.Expect(t, pages, 1)
+	expect.Expect(t, len(clients), 2)
+}
+
+func TestNew_WithRetries(t *testing.T) {
+	requests := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"statusCode":429,"error":"Too Many Requests","message":"Global limit has been reached"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithRetries(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.User.Read("123")
+
+	var mErr Error
+	if !errors.As(err, &mErr) {
+		t.Fatalf("expected err to be a management.Error, got %v", err)
+	}
+
+	expect.Expect(t, mErr.Status(), http.StatusTooManyRequests)
+	expect.Expect(t, requests, 2)
+}
+
+func TestManagementRateLimit(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "50")
+		w.Header().Set("X-RateLimit-Remaining", "49")
+		w.Header().Set("X-RateLimit-Reset", "1646300000")
+		w.Write([]byte(`{"user_id":"123"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, m.RateLimit(), RateLimitInfo{})
+
+	_, err = m.User.Read("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, m.RateLimit(), RateLimitInfo{
+		Limit:     50,
+		Remaining: 49,
+		Reset:     time.Unix(1646300000, 0),
+	})
+}
+
+func TestManagementRateLimitWithoutReset(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "50")
+		w.Header().Set("X-RateLimit-Remaining", "49")
+		w.Write([]byte(`{"user_id":"123"}`))
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.User.Read("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, m.RateLimit(), RateLimitInfo{Limit: 50, Remaining: 49})
+	expect.Expect(t, m.RateLimit().Reset.IsZero(), true)
+}
+
+func TestNew_WithContextAfterClientCredentials(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":86400}`))
+		case "/api/v2/users/123":
+			w.Write([]byte(`{"user_id":"123"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	// The context carries the HTTP client used when requesting a token. It
+	// must be used even though WithContext is applied after
+	// WithClientCredentials.
+	authenticated := false
+	tokenClient := s.Client()
+	base := tokenClient.Transport
+	tokenClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		authenticated = true
+		return base.RoundTrip(r)
+	})
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient)
+
+	m, err := New(s.URL,
+		WithClient(s.Client()),
+		WithClientCredentials("client-id", "client-secret"),
+		WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := m.User.Read("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, u.GetID(), "123")
+	expect.Expect(t, authenticated, true)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestManagementError(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/users/404":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The user does not exist.","errorCode":"inexistent_user"}`))
+		case "/api/v2/users/409":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"statusCode":409,"error":"Conflict","message":"The user already exists."}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`<html>Bad Gateway</html>`))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.User.Read("404")
+	var mErr *ManagementError
+	if !errors.As(err, &mErr) {
+		t.Fatalf("expected err to be a *ManagementError, got %v", err)
+	}
+	expect.Expect(t, mErr.ErrorCode, "inexistent_user")
+	expect.Expect(t, IsNotFound(err), true)
+	expect.Expect(t, IsConflict(err), false)
+
+	_, err = m.User.Read("409")
+	expect.Expect(t, IsConflict(err), true)
+
+	mErr, ok := AsManagementError(fmt.Errorf("wrapped: %w", err))
+	expect.Expect(t, ok, true)
+	expect.Expect(t, mErr.StatusCode, http.StatusConflict)
+	expect.Expect(t, mErr.ErrorCode, "")
+
+	_, ok = AsManagementError(errors.New("not a management error"))
+	expect.Expect(t, ok, false)
+	expect.Expect(t, IsNotFound(err), false)
+
+	_, err = m.User.Read("502")
+	if !errors.As(err, &mErr) {
+		t.Fatalf("expected err to be a *ManagementError, got %v", err)
+	}
+	expect.Expect(t, mErr.Status(), http.StatusBadGateway)
+	expect.Expect(t, mErr.Err, "Bad Gateway")
+}
+
+func TestNew_WithRequestInspector(t *testing.T) {
+	var inspected *http.Request
+	var payload []byte
+
+	stub := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(r.Body)
+			expect.Expect(t, string(b), string(payload)) // the body is still sent
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"id":"rol_123"}`)),
+				Request:    r,
+			}, nil
+		}),
+	}
+
+	m, err := New("example.com",
+		WithInsecure(),
+		WithClient(stub),
+		WithRequestInspector(func(r *http.Request) {
+			inspected = r
+			payload, _ = ioutil.ReadAll(r.Body)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Role{Name: auth0.String("admin")}
+	if err := m.Role.Create(r); err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, inspected.Method, "POST")
+	expect.Expect(t, inspected.URL.String(), "http://example.com/api/v2/roles")
+	expect.Expect(t, string(payload), `{"name":"admin"}`+"\n")
+	expect.Expect(t, r.GetID(), "rol_123")
+}
+
+func TestNew_WithDryRun(t *testing.T) {
+	var captured *http.Request
+	var payload []byte
+
+	stub := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatal("the request should not be sent")
+			return nil, nil
+		}),
+	}
+
+	m, err := New("example.com",
+		WithInsecure(),
+		WithStaticToken("token"),
+		WithClient(stub),
+		WithDryRun(func(r *http.Request) {
+			captured = r
+			payload, _ = ioutil.ReadAll(r.Body)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Role.Create(&Role{Name: auth0.String("admin")})
+	expect.Expect(t, err, ErrDryRun)
+
+	expect.Expect(t, captured.Method, "POST")
+	expect.Expect(t, captured.URL.String(), "http://example.com/api/v2/roles")
+	expect.Expect(t, captured.Header.Get("Authorization"), "Bearer token")
+	expect.Expect(t, string(payload), `{"name":"admin"}`+"\n")
+}
+
+type loggerFunc func(ctx context.Context, fields map[string]interface{})
+
+func (f loggerFunc) Log(ctx context.Context, fields map[string]interface{}) { f(ctx, fields) }
+
+func TestNew_WithLogger(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"rol_123"}`))
+	}))
+	defer s.Close()
+
+	var entries []map[string]interface{}
+	m, err := New(s.URL,
+		WithInsecure(),
+		WithStaticToken("token"),
+		WithLogger(loggerFunc(func(ctx context.Context, fields map[string]interface{}) {
+			entries = append(entries, fields)
+		})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Role.Read("rol_123", Parameter("session_token", "abc"), Header("X-Client-Secret", "def"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, len(entries), 1)
+	e := entries[0]
+	expect.Expect(t, e["method"], "GET")
+	expect.Expect(t, e["path"], "/api/v2/roles/rol_123")
+	expect.Expect(t, e["status"], http.StatusOK)
+	expect.Expect(t, e["query"].(url.Values).Get("session_token"), "[REDACTED]")
+	expect.Expect(t, e["header"].(http.Header).Get("X-Client-Secret"), "[REDACTED]")
+	expect.Expect(t, e["header"].(http.Header).Get("Content-Type"), "application/json")
+	if _, ok := e["duration"].(time.Duration); !ok {
+		t.Errorf("expected a duration, got %v", e["duration"])
+	}
+}
+
+type testSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errs       []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.errs = append(s.errs, err) }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct{ spans []*testSpan }
+
+func (t *testTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	s := &testSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func (t *testTracer) Inject(ctx context.Context, h http.Header) {
+	h.Set("Traceparent", "00-trace-span-01")
+}
+
+func TestNew_WithTracing(t *testing.T) {
+	var traceparent string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		if r.URL.Path == "/api/v2/roles/missing" {
+			http.Error(w, `{"statusCode":404,"message":"Not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"rol_123"}`))
+	}))
+	defer s.Close()
+
+	tracer := &testTracer{}
+	m, err := New(s.URL, WithInsecure(), WithTracing(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Role.Read("rol_123", Parameter("secret", "abc")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.Read("missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expect.Expect(t, len(tracer.spans), 2)
+	expect.Expect(t, traceparent, "00-trace-span-01")
+
+	span := tracer.spans[0]
+	expect.Expect(t, span.name, "GET roles")
+	expect.Expect(t, span.ended, true)
+	expect.Expect(t, span.attributes, map[string]interface{}{
+		"http.method":      "GET",
+		"http.url":         s.URL + "/api/v2/roles/rol_123",
+		"http.status_code": http.StatusOK,
+	})
+	expect.Expect(t, len(span.errs), 0)
+
+	span = tracer.spans[1]
+	expect.Expect(t, span.attributes["http.status_code"], http.StatusNotFound)
+	expect.Expect(t, len(span.errs), 1)
+}
+
+func TestNew_WithClientCredentialsAndClient(t *testing.T) {
+	var paths []string
+	custom := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			paths = append(paths, r.URL.Path)
+
+			body := `{}`
+			switch r.URL.Path {
+			case "/oauth/token":
+				body = `{"access_token":"token","token_type":"Bearer","expires_in":86400}`
+			case "/api/v2/roles/rol_123":
+				expect.Expect(t, r.Header.Get("Authorization"), "Bearer token")
+				body = `{"id":"rol_123"}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    r,
+			}, nil
+		}),
+	}
+
+	m, err := New("example.com",
+		WithInsecure(),
+		WithClient(custom),
+		WithClientCredentials("id", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := m.Role.Read("rol_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, r.GetID(), "rol_123")
+	expect.Expect(t, paths, []string{"/oauth/token", "/api/v2/roles/rol_123"})
+}
+
+func TestNew_WithHooks(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Header.Get("Authorization"), "Bearer token")
+		b, _ := ioutil.ReadAll(r.Body)
+		expect.Expect(t, string(b), `{"name":"admin"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"rol_123"}`))
+	}))
+	defer s.Close()
+
+	var reqAuth, reqBody, resBody string
+	m, err := New(s.URL,
+		WithInsecure(),
+		WithStaticToken("token"),
+		WithRequestHook(func(r *http.Request) {
+			reqAuth = r.Header.Get("Authorization")
+			b, _ := ioutil.ReadAll(r.Body)
+			reqBody = string(b)
+		}),
+		WithResponseHook(func(r *http.Response) {
+			b, _ := ioutil.ReadAll(r.Body)
+			resBody = string(b)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Role{Name: auth0.String("admin")}
+	if err := m.Role.Create(r); err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, reqAuth, "[REDACTED]")
+	expect.Expect(t, reqBody, `{"name":"admin"}`+"\n")
+	expect.Expect(t, resBody, `{"id":"rol_123"}`)
+	expect.Expect(t, r.GetID(), "rol_123")
+}
+
+func TestOptionETag(t *testing.T) {
+	etag := `"1"`
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(`{"id":"lst_123","name":"foo"}`))
+		case "PATCH":
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`{"statusCode":412,"error":"Precondition Failed","message":"The resource was modified"}`))
+				return
+			}
+			etag = `"2"`
+			w.Write([]byte(`{"id":"lst_123","name":"bar"}`))
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var read string
+	l, err := m.LogStream.Read("lst_123", ETag(&read))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, read, `"1"`)
+
+	update := &LogStream{Name: auth0.String("bar")}
+	if err := m.LogStream.Update(l.GetID(), update, IfMatch(read)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.LogStream.Update(l.GetID(), update, IfMatch(read))
+	if !IsPreconditionFailed(err) {
+		t.Errorf("expected a precondition failed error, got %v", err)
+	}
+}
+
+func TestOptionIfUnmodifiedSince(t *testing.T) {
+	modified := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since"))
+		if err != nil || modified.After(since) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"statusCode":412,"error":"Precondition Failed","message":"The resource was modified"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"lst_123","name":"bar"}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := m.NewRequest("PATCH", "/", nil, IfUnmodifiedSince(time.Date(2022, 3, 1, 13, 30, 0, 0, time.FixedZone("CET", 3600))))
+	expect.Expect(t, r.Header.Get("If-Unmodified-Since"), "Tue, 01 Mar 2022 12:30:00 GMT")
+
+	update := &LogStream{Name: auth0.String("bar")}
+	if err := m.LogStream.Update("lst_123", update, IfUnmodifiedSince(modified)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.LogStream.Update("lst_123", update, IfUnmodifiedSince(modified.Add(-time.Hour)))
+	if !IsPreconditionFailed(err) {
+		t.Errorf("expected a precondition failed error, got %v", err)
+	}
+}
+
+func TestNew_WithDefaultOptions(t *testing.T) {
+	m, err := New("example.com",
+		WithInsecure(),
+		WithDefaultOptions(
+			IncludeTotals(true),
+			PerPage(100),
+			Parameters(url.Values{"fields": {"id", "name"}}),
+			Header("X-Default", "default"),
+		))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := m.NewRequest("GET", m.URI("roles"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, r.URL.Query(), url.Values{
+		"include_totals": {"true"},
+		"per_page":       {"100"},
+		"fields":         {"id", "name"},
+	})
+	expect.Expect(t, r.Header.Get("X-Default"), "default")
+
+	r, err = m.NewRequest("GET", m.URI("roles"), nil,
+		PerPage(10),
+		Parameters(url.Values{"fields": {"description"}}),
+		Header("X-Default", "overridden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, r.URL.Query(), url.Values{
+		"include_totals": {"true"},
+		"per_page":       {"10"},
+		"fields":         {"description"},
+	})
+	expect.Expect(t, r.Header.Get("X-Default"), "overridden")
+}
+
+func TestNew_WithDefaultOptionsList(t *testing.T) {
+	var perPages []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPages = append(perPages, r.URL.Query().Get("per_page"))
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithDefaultOptions(IncludeTotals(true), PerPage(100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.User.List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.List(PerPage(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = New(s.URL, WithInsecure(), WithDefaultOptions(IncludeTotals(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, perPages, []string{"100", "100", "10", "50"})
+}
+
+func TestManagementConcurrentUse(t *testing.T) {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Header().Set("X-RateLimit-Limit", "10000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(10000-int(n)))
+		w.Header().Set("X-RateLimit-Reset", "1646136000")
+		if r.URL.Path == "/api/v2/log-streams" {
+			w.Write([]byte(`[{"id":"lst_1","type":"http","status":"active","sink":{}},{"id":"lst_2","type":"datadog","status":"paused","sink":{}}]`))
+			return
+		}
+		w.Write([]byte(`{"id":"lst_1","type":"http","sink":{"httpEndpoint":"https://example.com"}}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure(), WithLogger(loggerFunc(func(context.Context, map[string]interface{}) {})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				var ls []*LogStream
+				ls, err = m.LogStream.List(WithLogStreamStatus(LogStreamStatusActive))
+				if err == nil && len(ls) != 1 {
+					err = fmt.Errorf("expected 1 log stream, got %d", len(ls))
+				}
+			} else {
+				_, err = m.LogStream.Read("lst_1")
+			}
+			if err != nil {
+				errs <- err
+			}
+			_ = m.RateLimit()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	expect.Expect(t, atomic.LoadInt32(&requests), int32(50))
+	expect.Expect(t, m.RateLimit().Limit, 10000)
+}
+
+func TestNew_WithMaxBodyBytes(t *testing.T) {
+	payload := &LogStream{
+		Name: auth0.String("large"),
+		Sink: &LogStreamSinkHTTP{Endpoint: auth0.String("https://example.com/" + strings.Repeat("a", 200))},
+	}
+
+	m, err := New("example.com", WithInsecure(), WithMaxBodyBytes(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.NewRequest("POST", m.URI("log-streams"), payload)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	expect.Expect(t, err.Error(), `request payload of 264 bytes exceeds the limit of 100 bytes, the largest field being "sink" with 239 bytes`)
+
+	m, err = New("example.com", WithInsecure(), WithMaxBodyBytes(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.NewRequest("POST", m.URI("log-streams"), payload); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNew_Domain(t *testing.T) {
+	for _, test := range []struct {
+		domain, uri, tokenURL string
+	}{
+		{"tenant.eu.auth0.com", "https://tenant.eu.auth0.com/api/v2/users/123", "https://tenant.eu.auth0.com/oauth/token"},
+		{"https://tenant.eu.auth0.com/", "https://tenant.eu.auth0.com/api/v2/users/123", "https://tenant.eu.auth0.com/oauth/token"},
+		{"https://custom.example.com/auth0", "https://custom.example.com/auth0/api/v2/users/123", "https://custom.example.com/auth0/oauth/token"},
+		{"custom.example.com/auth0/", "https://custom.example.com/auth0/api/v2/users/123", "https://custom.example.com/auth0/oauth/token"},
+	} {
+		var requested []string
+		stub := &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				requested = append(requested, r.URL.String())
+				body := `{"id":"123"}`
+				if strings.HasSuffix(r.URL.Path, "/oauth/token") {
+					body = `{"access_token":"token","token_type":"Bearer","expires_in":86400}`
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+					Request:    r,
+				}, nil
+			}),
+		}
+
+		m, err := New(test.domain, WithClientCredentials("id", "secret"), WithClient(stub))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, m.URI("users", "123"), test.uri)
+
+		if _, err := m.User.Read("123"); err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, requested, []string{test.tokenURL, test.uri})
+	}
+}
+
+func TestManagementCount(t *testing.T) {
+	var queries []url.Values
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		fmt.Fprintf(w, `{"start":0,"limit":0,"length":0,"total":%d}`, len(r.URL.Path))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		count func(opts ...RequestOption) (int, error)
+		total int
+	}{
+		{m.User.Count, len("/api/v2/users")},
+		{m.Role.Count, len("/api/v2/roles")},
+		{m.Connection.Count, len("/api/v2/connections")},
+		{m.Log.Count, len("/api/v2/logs")},
+	} {
+		queries = nil
+		n, err := test.count(Query(`email:"alice@example.com"`), PerPage(50))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, n, test.total)
+		expect.Expect(t, len(queries), 1)
+		expect.Expect(t, queries[0].Get("per_page"), "0")
+		expect.Expect(t, queries[0].Get("include_totals"), "true")
+		expect.Expect(t, queries[0].Get("q"), `email:"alice@example.com"`)
+	}
+}
+
+type testCodec struct {
+	marshaled, unmarshaled int
+}
+
+func (c *testCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshaled++
+	return json.Marshal(v)
+}
+
+func (c *testCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshaled++
+	return json.Unmarshal(data, v)
+}
+
+func TestNew_WithCodec(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/api/v2/roles":
+			expect.Expect(t, string(b), `{"name":"admin"}`)
+			w.Write([]byte(`{"id":"rol_123"}`))
+		case "/api/v2/log-streams":
+			expect.Expect(t, string(b), `{"name":"foo","type":"sumo","sink":{"sumoSourceAddress":"https://example.com"}}`)
+			w.Write([]byte(`{"id":"lst_123","type":"sumo","sink":{"sumoSourceAddress":"https://example.com"}}`))
+		}
+	}))
+	defer s.Close()
+
+	c := &testCodec{}
+	m, err := New(s.URL, WithInsecure(), WithCodec(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Role{Name: auth0.String("admin")}
+	if err := m.Role.Create(r); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, r.GetID(), "rol_123")
+	expect.Expect(t, *c, testCodec{marshaled: 1, unmarshaled: 1})
+
+	l := &LogStream{
+		Name: auth0.String("foo"),
+		Type: auth0.String(LogStreamTypeSumo),
+		Sink: &LogStreamSinkSumo{SourceAddress: auth0.String("https://example.com")},
+	}
+	if err := m.LogStream.Create(l); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := l.Sink.(*LogStreamSinkSumo); !ok {
+		t.Errorf("unexpected sink type %T", l.Sink)
+	}
+	expect.Expect(t, *c, testCodec{marshaled: 1, unmarshaled: 1})
+}
+
+func TestOptionCaptureResponse(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "abc")
+		switch r.URL.Path {
+		case "/api/v2/roles/rol_123":
+			w.Write([]byte(`{"id":"rol_123"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The role does not exist"}`))
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res *http.Response
+	r, err := m.Role.Read("rol_123", CaptureResponse(&res))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, r.GetID(), "rol_123")
+	expect.Expect(t, res.StatusCode, http.StatusOK)
+	expect.Expect(t, res.Header.Get("X-Request-ID"), "abc")
+	expect.Expect(t, res.Body, http.NoBody)
+
+	res = nil
+	_, err = m.Role.Read("rol_456", CaptureResponse(&res))
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	expect.Expect(t, res.StatusCode, http.StatusNotFound)
+}
+
+func TestOptionResponseOptionsList(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"roles"`)
+		w.Header().Set("X-Request-ID", "abc")
+		w.Write([]byte(`{"roles":[{"id":"rol_123"}]}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res *http.Response
+	var etag string
+	l, err := m.Role.List(CaptureResponse(&res), ETag(&etag))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, len(l.Roles), 1)
+	expect.Expect(t, res.Header.Get("X-Request-ID"), "abc")
+	expect.Expect(t, etag, `W/"roles"`)
+}
+
+func TestOptionGzipBody(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Header.Get("Content-Encoding"), "gzip")
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(gr)
+		expect.Expect(t, string(b), `{"name":"admin"}`+"\n")
+
+		payload := `{"id":"rol_123"}`
+		if r.Header.Get("Accept-Encoding") == "gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			gw.Write([]byte(payload))
+			gw.Close()
+			return
+		}
+		w.Write([]byte(payload))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range [][]RequestOption{
+		{GzipBody()},
+		{GzipBody(), Header("Accept-Encoding", "gzip")},
+	} {
+		r := &Role{Name: auth0.String("admin")}
+		if err := m.Role.Create(r, opts...); err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, r.GetID(), "rol_123")
+	}
+}
+
+func TestManagementPing(t *testing.T) {
+	status := http.StatusOK
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Path, "/api/v2/tenants/settings")
+		expect.Expect(t, r.URL.Query().Get("fields"), "friendly_name")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"statusCode":%d,"error":"%s","message":"Ping"}`, status, http.StatusText(status))
+	}))
+
+	m, err := New(s.URL, WithInsecure(), WithRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status = range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		err = m.Ping(context.Background())
+		expect.Expect(t, errors.Is(err, ErrAuthentication), true)
+		mErr, _ := AsManagementError(err)
+		expect.Expect(t, mErr.Status(), status)
+	}
+
+	status = http.StatusInternalServerError
+	err = m.Ping(context.Background())
+	expect.Expect(t, errors.Is(err, ErrAuthentication), false)
+	mErr, _ := AsManagementError(err)
+	expect.Expect(t, mErr.Status(), status)
+
+	s.Close()
+	err = m.Ping(context.Background())
+	if _, ok := AsManagementError(err); err == nil || ok || errors.Is(err, ErrAuthentication) {
+		t.Errorf("expected a network error, got %v", err)
+	}
+}
+
+func TestManagementTokenExpiry(t *testing.T) {
+	var tokens int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			atomic.AddInt32(&tokens, 1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":86400}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithClient(s.Client()), WithClientCredentials("client-id", "client-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, m.TokenExpiry().IsZero(), true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := m.User.Read("123"); err != nil {
+				t.Error(err)
+			}
+			if _, err := m.Role.Read("rol_123"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	expect.Expect(t, atomic.LoadInt32(&tokens), int32(1))
+	if d := time.Until(m.TokenExpiry()); d < 23*time.Hour || d > 24*time.Hour {
+		t.Errorf("expected the token to expire in a day, got %s", d)
+	}
+
+	m, err = New(s.URL, WithStaticToken("static"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, m.TokenExpiry().IsZero(), true)
+}
+
+func TestNew_WithClientCredentialsScopes(t *testing.T) {
+	var form url.Values
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			r.ParseForm()
+			form = r.PostForm
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":86400,"scope":"read:logs"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"statusCode":403,"error":"Forbidden","message":"Insufficient scope, expected any of: update:log_streams","errorCode":"insufficient_scope"}`))
+		}
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL,
+		WithClient(s.Client()),
+		WithClientCredentials("client-id", "client-secret"),
+		WithClientCredentialsAudience("https://tenant.eu.auth0.com/api/v2/"),
+		WithClientCredentialsScopes("read:logs", "read:log_streams"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.LogStream.Update("lst_123", &LogStream{Name: auth0.String("foo")})
+	expect.Expect(t, form.Get("scope"), "read:logs read:log_streams")
+	expect.Expect(t, form.Get("audience"), "https://tenant.eu.auth0.com/api/v2/")
+	expect.Expect(t, IsInsufficientScope(err), true)
+	expect.Expect(t, err.Error(), "403 Forbidden: this call requires update:log_streams")
+
+	mErr, _ := AsManagementError(err)
+	expect.Expect(t, mErr.RequiredScopes(), []string{"update:log_streams"})
+
+	expect.Expect(t, IsInsufficientScope(&ManagementError{StatusCode: http.StatusForbidden, Message: "Forbidden"}), false)
+	expect.Expect(t, IsInsufficientScope(errors.New("insufficient scope")), false)
+}
+
+func TestNew_WithTokenRefreshMargin(t *testing.T) {
+	var tokens, rejected int32
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			n := atomic.AddInt32(&tokens, 1)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+		default:
+			// The first access token is considered expired by the server.
+			if r.Header.Get("Authorization") == "Bearer token-1" {
+				atomic.AddInt32(&rejected, 1)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"statusCode":401,"error":"Unauthorized","message":"Expired token received for JSON Web Token validation"}`))
+				return
+			}
+			w.Write([]byte(`{"id":"rol_123"}`))
+		}
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithClient(s.Client()), WithClientCredentials("client-id", "client-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := m.Role.Update("rol_123", &Role{Name: auth0.String("foo")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect.Expect(t, atomic.LoadInt32(&rejected), int32(1))
+	expect.Expect(t, atomic.LoadInt32(&tokens), int32(2))
+
+	// A margin longer than the lifetime of the access tokens refreshes them
+	// before every request.
+	m, err = New(s.URL,
+		WithClient(s.Client()),
+		WithClientCredentials("client-id", "client-secret"),
+		WithTokenRefreshMargin(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt32(&tokens, 1)
+	for i := 0; i < 2; i++ {
+		if _, err := m.Role.Read("rol_123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect.Expect(t, atomic.LoadInt32(&tokens), int32(3))
+}
+
+func TestNew_WithTokenSource(t *testing.T) {
+	var paths []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer external" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"statusCode":401,"error":"Unauthorized","message":"Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"rol_123"}`))
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL,
+		WithClient(s.Client()),
+		WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "external"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.Read("rol_123"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = New(s.URL, WithClient(s.Client()), WithStaticToken("expired"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.Role.Read("rol_123")
+	expect.Expect(t, err.Error(), "401 Unauthorized: access token rejected or expired: Invalid token")
+	expect.Expect(t, paths, []string{"/api/v2/roles/rol_123", "/api/v2/roles/rol_123"})
+}
+
+func TestNew_WithUserAgentProduct(t *testing.T) {
+	var userAgents []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		if r.URL.Path == "/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"123","token_type":"Bearer","expires_in":86400}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL,
+		WithClient(s.Client()),
+		WithClientCredentials("client-id", "client-secret"),
+		WithUserAgentProduct("myapp", "1.2.3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Role.Read("rol_123"); err != nil {
+		t.Fatal(err)
+	}
+
+	userAgent := fmt.Sprintf("Go-Auth0-SDK/%s myapp/1.2.3", auth0.Version)
+	expect.Expect(t, userAgents, []string{userAgent, userAgent})
+
+	// The product is appended to a custom user agent in either order.
+	for _, options := range [][]Option{
+		{WithUserAgent("custom/1.0"), WithUserAgentProduct("myapp", "1.2.3")},
+		{WithUserAgentProduct("myapp", "1.2.3"), WithUserAgent("custom/1.0")},
+	} {
+		userAgents = nil
+		m, err := New(s.URL, append([]Option{WithClient(s.Client()), WithStaticToken("token")}, options...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.Role.Read("rol_123"); err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, userAgents, []string{"custom/1.0 myapp/1.2.3"})
+	}
+}
+
+func TestOptionSort(t *testing.T) {
+	var sorts []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sorts = append(sorts, r.URL.Query().Get("sort"))
+		if r.URL.Path == "/api/v2/users" {
+			w.Write([]byte(`{"users":[]}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Log.List(Sort("date", false)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.User.List(Sort("created_at", true)); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, sorts, []string{"date:-1", "created_at:1"})
+}
+
+func TestOptionContentType(t *testing.T) {
+	var attempts int
+	var contentTypes, bodies []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		b, _ := ioutil.ReadAll(r.Body)
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		bodies = append(bodies, string(b))
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"job_123"}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := strings.NewReader("--boundary\r\n\r\n[]\r\n--boundary--\r\n")
+	var job Job
+	err = m.Call("POST", "jobs/users-imports", body, &job, ContentType("multipart/form-data; boundary=boundary"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, job.GetID(), "job_123")
+
+	err = m.Request("POST", m.URI("raw"), []byte("plain"), ContentType("text/plain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multipart := "--boundary\r\n\r\n[]\r\n--boundary--\r\n"
+	expect.Expect(t, contentTypes, []string{
+		"multipart/form-data; boundary=boundary",
+		"multipart/form-data; boundary=boundary",
+		"text/plain",
+	})
+	expect.Expect(t, bodies, []string{multipart, multipart, "plain"})
+}
@@ -0,0 +1,195 @@
+// Package managementtest provides an in-memory Auth0 Management API server,
+// making it possible to test code using the management package without an
+// Auth0 tenant.
+//
+// Only log streams are supported for now. For example:
+//
+//   s, m := managementtest.NewTestServer()
+//   defer s.Close()
+//
+//   err := m.LogStream.Create(&management.LogStream{...})
+package managementtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+// Server is an in-memory Auth0 Management API server.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	logStreams map[string]map[string]interface{}
+	lastID     int
+	errorHook  func(r *http.Request) int
+}
+
+// NewTestServer starts a Server and returns it along with a Management client
+// configured to use it. The caller should call Close when finished, to shut
+// the server down.
+//
+// Additional options are passed to management.New as is.
+func NewTestServer(options ...management.Option) (*Server, *management.Management) {
+	s := &Server{logStreams: make(map[string]map[string]interface{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	m, err := management.New(s.URL, append([]management.Option{
+		management.WithInsecure(),
+		management.WithRetries(0),
+	}, options...)...)
+	if err != nil {
+		s.Close()
+		panic(fmt.Sprintf("managementtest: failed to create the management client: %v", err))
+	}
+
+	return s, m
+}
+
+// SetErrorHook configures the server to call hook before handling every
+// request. If hook returns a non-zero status code, the request fails with that
+// status code instead of being handled, which makes it possible to test
+// failures. Passing nil removes the hook.
+func (s *Server) SetErrorHook(hook func(r *http.Request) int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errorHook = hook
+}
+
+// LogStreams returns the log streams held by the server, as they would be
+// returned by the Management API.
+func (s *Server) LogStreams() []*management.LogStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ls []*management.LogStream
+	for _, raw := range s.sortedLogStreams() {
+		var l *management.LogStream
+		b, _ := json.Marshal(raw)
+		if err := json.Unmarshal(b, &l); err == nil {
+			ls = append(ls, l)
+		}
+	}
+	return ls
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.errorHook != nil {
+		if status := s.errorHook(r); status != 0 {
+			writeError(w, status, "Injected failure")
+			return
+		}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v2/")
+	switch {
+	case path == "log-streams":
+		s.handleLogStreams(w, r)
+	case strings.HasPrefix(path, "log-streams/"):
+		s.handleLogStream(w, r, strings.TrimPrefix(path, "log-streams/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not supported by managementtest")
+	}
+}
+
+func (s *Server) handleLogStreams(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, s.sortedLogStreams())
+	case "POST":
+		var l map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil || l == nil {
+			writeError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+		s.lastID++
+		l["id"] = fmt.Sprintf("lst_%016d", s.lastID)
+		if _, ok := l["status"]; !ok {
+			l["status"] = management.LogStreamStatusActive
+		}
+		s.logStreams[l["id"].(string)] = l
+		writeJSON(w, http.StatusOK, l)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) handleLogStream(w http.ResponseWriter, r *http.Request, id string) {
+	l, ok := s.logStreams[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The log stream does not exist")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, l)
+	case "PATCH":
+		var patch map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+		for k, v := range patch {
+			switch k {
+			case "id", "type":
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("Field %q cannot be updated", k))
+				return
+			case "sink":
+				sink, _ := l["sink"].(map[string]interface{})
+				if sink == nil {
+					sink = make(map[string]interface{})
+				}
+				patchSink, _ := v.(map[string]interface{})
+				for sk, sv := range patchSink {
+					sink[sk] = sv
+				}
+				l["sink"] = sink
+			default:
+				l[k] = v
+			}
+		}
+		writeJSON(w, http.StatusOK, l)
+	case "DELETE":
+		delete(s.logStreams, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// sortedLogStreams returns the log streams in the order they were created.
+func (s *Server) sortedLogStreams() []map[string]interface{} {
+	ls := make([]map[string]interface{}, 0, len(s.logStreams))
+	for _, l := range s.logStreams {
+		ls = append(ls, l)
+	}
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i]["id"].(string) < ls[j]["id"].(string)
+	})
+	return ls
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &management.ManagementError{
+		StatusCode: status,
+		Err:        http.StatusText(status),
+		Message:    message,
+	})
+}
@@ -0,0 +1,92 @@
+package managementtest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/management"
+)
+
+func TestServerLogStreams(t *testing.T) {
+	s, m := NewTestServer()
+	defer s.Close()
+
+	l := &management.LogStream{
+		Name: auth0.String("Test-LogStream"),
+		Type: auth0.String(management.LogStreamTypeHTTP),
+		Sink: &management.LogStreamSinkHTTP{
+			Endpoint:      auth0.String("https://example.com/logs"),
+			Authorization: auth0.String("Bearer token"),
+			ContentType:   auth0.String("application/json"),
+		},
+	}
+	if err := m.LogStream.Create(l); err != nil {
+		t.Fatal(err)
+	}
+	if l.GetID() == "" || l.GetStatus() != management.LogStreamStatusActive {
+		t.Fatalf("unexpected log stream %v", l)
+	}
+
+	err := m.LogStream.Update(l.GetID(), &management.LogStream{
+		Sink: &management.LogStreamSinkHTTP{Endpoint: auth0.String("https://example.com/v2/logs")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err = m.LogStream.Read(l.GetID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink, ok := l.Sink.(*management.LogStreamSinkHTTP)
+	if !ok || sink.GetEndpoint() != "https://example.com/v2/logs" || sink.GetAuthorization() != "Bearer token" {
+		t.Fatalf("unexpected sink %v", l.Sink)
+	}
+
+	ls, err := m.LogStream.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls) != 1 || len(s.LogStreams()) != 1 {
+		t.Fatalf("expected 1 log stream, got %d", len(ls))
+	}
+
+	if err := m.LogStream.Delete(l.GetID()); err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.LogStream.Read(l.GetID())
+	if mErr, ok := err.(management.Error); !ok || mErr.Status() != http.StatusNotFound {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestServerErrorHook(t *testing.T) {
+	s, m := NewTestServer()
+	defer s.Close()
+
+	s.SetErrorHook(func(r *http.Request) int {
+		if r.Method == "POST" {
+			return http.StatusInternalServerError
+		}
+		return 0
+	})
+
+	err := m.LogStream.Create(&management.LogStream{
+		Name: auth0.String("Test-LogStream"),
+		Type: auth0.String(management.LogStreamTypeSumo),
+		Sink: &management.LogStreamSinkSumo{SourceAddress: auth0.String("https://example.com")},
+	})
+	if mErr, ok := err.(management.Error); !ok || mErr.Status() != http.StatusInternalServerError {
+		t.Fatalf("expected a 500 error, got %v", err)
+	}
+
+	if _, err := m.LogStream.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetErrorHook(nil)
+	if len(s.LogStreams()) != 0 {
+		t.Fatal("expected no log streams to be created")
+	}
+}
@@ -1,5 +1,7 @@
 package management
 
+import "errors"
+
 // Organization is used to allow B2B customers to better manage
 // their partners and customers, and to customize the ways that
 // end-users access their applications.
@@ -185,7 +187,7 @@ func newOrganizationManager(m *Management) *OrganizationManager {
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/get_organizations
 func (m *OrganizationManager) List(opts ...RequestOption) (o *OrganizationList, err error) {
-	err = m.Request("GET", m.URI("organizations"), &o, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("organizations"), &o, applyListDefaults(opts)...)
 	return
 }
 
@@ -233,7 +235,7 @@ func (m *OrganizationManager) ReadByName(name string, opts ...RequestOption) (o
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/get_enabled_connections
 func (m *OrganizationManager) Connections(id string, opts ...RequestOption) (c *OrganizationConnectionList, err error) {
-	err = m.Request("GET", m.URI("organizations", id, "enabled_connections"), &c, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("organizations", id, "enabled_connections"), &c, applyListDefaults(opts)...)
 	return
 }
 
@@ -273,7 +275,7 @@ func (m *OrganizationManager) UpdateConnection(id string, connectionID string, c
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/get_invitations
 func (m *OrganizationManager) Invitations(id string, opts ...RequestOption) (i *OrganizationInvitationList, err error) {
-	err = m.Request("GET", m.URI("organizations", id, "invitations"), &i, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("organizations", id, "invitations"), &i, applyListDefaults(opts)...)
 	return
 }
 
@@ -305,41 +307,69 @@ func (m *OrganizationManager) DeleteInvitation(id string, invitationID string, o
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/get_members
 func (m *OrganizationManager) Members(id string, opts ...RequestOption) (o *OrganizationMemberList, err error) {
-	err = m.Request("GET", m.URI("organizations", id, "members"), &o, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("organizations", id, "members"), &o, applyListDefaults(opts)...)
 	return
 }
 
+// organizationMembersBatchSize is the maximum amount of members which can be
+// added to or deleted from an organization in a single request.
+const organizationMembersBatchSize = 10
+
 // AddMembers adds members to an organization.
 //
+// Members are added in batches of at most 10 members, as documented by the
+// API. If a batch fails, the error is returned and the remaining batches are
+// not sent.
+//
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/post_members
 func (m *OrganizationManager) AddMembers(id string, memberIDs []string, opts ...RequestOption) (err error) {
-	body := struct {
-		Members []string `json:"members"`
-	}{
-		Members: memberIDs,
-	}
-	err = m.Request("POST", m.URI("organizations", id, "members"), &body, opts...)
-	return
+	return m.members("POST", id, memberIDs, opts...)
 }
 
-// DeleteMember deletes members from an organization.
+// DeleteMembers deletes members from an organization.
+//
+// Members are deleted in batches of at most 10 members, as documented by the
+// API. If a batch fails, the error is returned and the remaining batches are
+// not sent.
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/delete_members
+func (m *OrganizationManager) DeleteMembers(id string, memberIDs []string, opts ...RequestOption) (err error) {
+	return m.members("DELETE", id, memberIDs, opts...)
+}
+
+// DeleteMember deletes members from an organization.
+//
+// Deprecated: use DeleteMembers instead.
 func (m *OrganizationManager) DeleteMember(id string, memberIDs []string, opts ...RequestOption) (err error) {
-	body := struct {
-		Members []string `json:"members"`
-	}{
-		Members: memberIDs,
+	return m.DeleteMembers(id, memberIDs, opts...)
+}
+
+func (m *OrganizationManager) members(method, id string, memberIDs []string, opts ...RequestOption) error {
+	if len(memberIDs) == 0 {
+		return errors.New("at least one member is required")
 	}
-	err = m.Request("DELETE", m.URI("organizations", id, "members"), &body, opts...)
-	return
+	for start := 0; start < len(memberIDs); start += organizationMembersBatchSize {
+		end := start + organizationMembersBatchSize
+		if end > len(memberIDs) {
+			end = len(memberIDs)
+		}
+		body := struct {
+			Members []string `json:"members"`
+		}{
+			Members: memberIDs[start:end],
+		}
+		if err := m.Request(method, m.URI("organizations", id, "members"), &body, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // MemberRoles retrieves the roles assigned to an organization member.
 //
 // See: https://auth0.com/docs/api/management/v2/#!/Organizations/get_organization_member_roles
 func (m *OrganizationManager) MemberRoles(id string, memberID string, opts ...RequestOption) (r *OrganizationMemberRoleList, err error) {
-	err = m.Request("GET", m.URI("organizations", id, "members", memberID, "roles"), &r, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("organizations", id, "members", memberID, "roles"), &r, applyListDefaults(opts)...)
 	return
 }
 
@@ -1,12 +1,16 @@
 package management
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestOrganization(t *testing.T) {
@@ -241,7 +245,7 @@ func TestOrganization(t *testing.T) {
 	})
 
 	t.Run("DeleteMember", func(t *testing.T) {
-		err = m.Organization.DeleteMember(o.GetID(), []string{user.GetID()})
+		err = m.Organization.DeleteMembers(o.GetID(), []string{user.GetID()})
 		if err != nil {
 			t.Error(err)
 		}
@@ -270,3 +274,42 @@ func TestOrganization(t *testing.T) {
 		}
 	})
 }
+
+func TestOrganizationManagerMembersBatch(t *testing.T) {
+	batches := map[string][]int{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.URL.Path, "/api/v2/organizations/org_123/members")
+		var body struct {
+			Members []string `json:"members"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batches[r.Method] = append(batches[r.Method], len(body.Members))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for i := 0; i < 25; i++ {
+		ids = append(ids, fmt.Sprintf("auth0|%d", i))
+	}
+
+	if err := m.Organization.AddMembers("org_123", ids); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Organization.DeleteMembers("org_123", ids[:10]); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, batches, map[string][]int{
+		"POST":   {10, 10, 5},
+		"DELETE": {10},
+	})
+
+	if err := m.Organization.AddMembers("org_123", nil); err == nil {
+		t.Error("expected an empty list of members to be rejected")
+	}
+}
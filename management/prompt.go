@@ -1,5 +1,7 @@
 package management
 
+import "fmt"
+
 // Prompt is used within the Login Page.
 //
 // See: https://auth0.com/docs/customize/universal-login-pages/customize-login-text-prompts
@@ -39,18 +41,104 @@ func (m *PromptManager) Update(p *Prompt, opts ...RequestOption) error {
 	return m.Request("PATCH", m.URI("prompts"), p, opts...)
 }
 
+// promptNames holds the names of the prompts which can be customized.
+var promptNames = map[string]bool{
+	"login":                       true,
+	"login-id":                    true,
+	"login-password":              true,
+	"login-passwordless":          true,
+	"login-email-verification":    true,
+	"signup":                      true,
+	"signup-id":                   true,
+	"signup-password":             true,
+	"phone-identifier-enrollment": true,
+	"phone-identifier-challenge":  true,
+	"reset-password":              true,
+	"consent":                     true,
+	"logout":                      true,
+	"mfa-push":                    true,
+	"mfa-otp":                     true,
+	"mfa-voice":                   true,
+	"mfa-phone":                   true,
+	"mfa-webauthn":                true,
+	"mfa-sms":                     true,
+	"mfa-email":                   true,
+	"mfa-recovery-code":           true,
+	"mfa":                         true,
+	"status":                      true,
+	"device-flow":                 true,
+	"email-verification":          true,
+	"email-otp-challenge":         true,
+	"organizations":               true,
+	"invitation":                  true,
+	"common":                      true,
+}
+
+// promptPartialNames holds the names of the prompts which support partials.
+var promptPartialNames = map[string]bool{
+	"login":           true,
+	"login-id":        true,
+	"login-password":  true,
+	"signup":          true,
+	"signup-id":       true,
+	"signup-password": true,
+}
+
+func validatePrompt(names map[string]bool, p string) error {
+	if !names[p] {
+		return fmt.Errorf("unknown prompt %q", p)
+	}
+	return nil
+}
+
 // CustomText retrieves the custom text for a specific prompt and language.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Prompts/get_custom_text_by_language
 func (m *PromptManager) CustomText(p string, l string, opts ...RequestOption) (t map[string]interface{}, err error) {
+	if err := validatePrompt(promptNames, p); err != nil {
+		return nil, err
+	}
 	err = m.Request("GET", m.URI("prompts", p, "custom-text", l), &t, opts...)
 	return
 }
 
 // SetCustomText sets the custom text for a specific prompt. Existing texts will be overwritten.
 //
+// The texts are keyed by screen, e.g. {"login": {"title": "Welcome"}}.
+//
 // See: https://auth0.com/docs/api/management/v2#!/Prompts/put_custom_text_by_language
 func (m *PromptManager) SetCustomText(p string, l string, b map[string]interface{}, opts ...RequestOption) (err error) {
+	if err := validatePrompt(promptNames, p); err != nil {
+		return err
+	}
 	err = m.Request("PUT", m.URI("prompts", p, "custom-text", l), &b, opts...)
 	return
 }
+
+// Partials retrieves the template partials of a specific prompt. Only the
+// login, login-id, login-password, signup, signup-id and signup-password
+// prompts support partials.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Prompts/get_partials
+func (m *PromptManager) Partials(p string, opts ...RequestOption) (t map[string]interface{}, err error) {
+	if err := validatePrompt(promptPartialNames, p); err != nil {
+		return nil, err
+	}
+	err = m.Request("GET", m.URI("prompts", p, "partials"), &t, opts...)
+	return
+}
+
+// SetPartials sets the template partials of a specific prompt. Existing
+// partials will be overwritten.
+//
+// The partials are keyed by screen and insertion point, e.g.
+// {"login": {"form-content-start": "<div>...</div>"}}.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Prompts/put_partials
+func (m *PromptManager) SetPartials(p string, b map[string]interface{}, opts ...RequestOption) (err error) {
+	if err := validatePrompt(promptPartialNames, p); err != nil {
+		return err
+	}
+	err = m.Request("PUT", m.URI("prompts", p, "partials"), &b, opts...)
+	return
+}
@@ -2,6 +2,9 @@ package management
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/auth0/go-auth0"
@@ -93,3 +96,42 @@ func TestPromptCustomText(t *testing.T) {
 		expect.Expect(t, body["login"].(map[string]interface{})["title"], "Welcome")
 	})
 }
+
+func TestPromptManagerPartials(t *testing.T) {
+	var paths, bodies []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, string(b))
+		w.Write([]byte(`{"login":{"form-content-start":"<div>Hello</div>"}}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partials := map[string]interface{}{
+		"login": map[string]interface{}{"form-content-start": "<div>Hello</div>"},
+	}
+	if err := m.Prompt.SetPartials("login", partials); err != nil {
+		t.Fatal(err)
+	}
+	p, err := m.Prompt.Partials("login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, p, partials)
+	expect.Expect(t, paths, []string{"PUT /api/v2/prompts/login/partials", "GET /api/v2/prompts/login/partials"})
+	expect.Expect(t, bodies[0], `{"login":{"form-content-start":"\u003cdiv\u003eHello\u003c/div\u003e"}}`+"\n")
+
+	paths = nil
+	err = m.Prompt.SetPartials("mfa", partials)
+	expect.Expect(t, err.Error(), `unknown prompt "mfa"`)
+	_, err = m.Prompt.CustomText("login-typo", "en")
+	expect.Expect(t, err.Error(), `unknown prompt "login-typo"`)
+	err = m.Prompt.SetCustomText("login-typo", "en", map[string]interface{}{})
+	expect.Expect(t, err.Error(), `unknown prompt "login-typo"`)
+	expect.Expect(t, len(paths), 0)
+}
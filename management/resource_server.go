@@ -108,7 +108,7 @@ func (m *ResourceServerManager) Delete(id string, opts ...RequestOption) (err er
 //
 // See: https://auth0.com/docs/api/management/v2#!/Resource_Servers/get_resource_servers
 func (m *ResourceServerManager) List(opts ...RequestOption) (rl *ResourceServerList, err error) {
-	err = m.Request("GET", m.URI("resource-servers"), &rl, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("resource-servers"), &rl, applyListDefaults(opts)...)
 	return
 }
 
@@ -130,3 +130,80 @@ func (m *ResourceServerManager) Stream(fn func(s *ResourceServer), opts ...Reque
 	}
 	return nil
 }
+
+// AddScopes adds scopes to a resource server.
+//
+// The scopes of the resource server are read, updated and sent back in a PATCH
+// operation. Scopes are identified by their Value: a scope which already
+// exists is left unchanged, preserving its description. If all the scopes
+// already exist no update is made. Note that concurrent changes to the scopes
+// of the same resource server may be overwritten.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Resource_Servers/patch_resource_servers_by_id
+func (m *ResourceServerManager) AddScopes(id string, scopes []*ResourceServerScope, opts ...RequestOption) error {
+	rs, err := m.Read(id, opts...)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(rs.Scopes))
+	for _, s := range rs.Scopes {
+		existing[s.GetValue()] = true
+	}
+
+	updated := rs.Scopes
+	for _, s := range scopes {
+		if !existing[s.GetValue()] {
+			existing[s.GetValue()] = true
+			updated = append(updated, s)
+		}
+	}
+	if len(updated) == len(rs.Scopes) {
+		return nil
+	}
+
+	return m.updateScopes(id, updated, opts...)
+}
+
+// RemoveScopes removes the scopes with the given values from a resource
+// server.
+//
+// The scopes of the resource server are read, updated and sent back in a PATCH
+// operation. If none of the scopes exist no update is made. Note that
+// concurrent changes to the scopes of the same resource server may be
+// overwritten.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Resource_Servers/patch_resource_servers_by_id
+func (m *ResourceServerManager) RemoveScopes(id string, values []string, opts ...RequestOption) error {
+	rs, err := m.Read(id, opts...)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]bool, len(values))
+	for _, v := range values {
+		remove[v] = true
+	}
+
+	updated := make([]*ResourceServerScope, 0, len(rs.Scopes))
+	for _, s := range rs.Scopes {
+		if !remove[s.GetValue()] {
+			updated = append(updated, s)
+		}
+	}
+	if len(updated) == len(rs.Scopes) {
+		return nil
+	}
+
+	return m.updateScopes(id, updated, opts...)
+}
+
+func (m *ResourceServerManager) updateScopes(id string, scopes []*ResourceServerScope, opts ...RequestOption) error {
+	// The scopes are sent without omitempty, so that removing the last scope
+	// sends an empty array instead of leaving them unchanged.
+	payload := &struct {
+		Scopes []*ResourceServerScope `json:"scopes"`
+	}{scopes}
+
+	return m.Request("PATCH", m.URI("resource-servers", id), payload, opts...)
+}
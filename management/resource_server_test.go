@@ -1,10 +1,14 @@
 package management
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestResourceServer(t *testing.T) {
@@ -74,3 +78,52 @@ func TestResourceServer(t *testing.T) {
 		}
 	})
 }
+
+func TestResourceServerManagerScopes(t *testing.T) {
+	var patches []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":"rs_123","scopes":[{"value":"read:foo","description":"Read foo"}]}`))
+		case "PATCH":
+			b, _ := ioutil.ReadAll(r.Body)
+			patches = append(patches, string(b))
+			w.Write([]byte(`{"id":"rs_123"}`))
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.ResourceServer.AddScopes("rs_123", []*ResourceServerScope{
+		{Value: auth0.String("read:foo"), Description: auth0.String("Overwritten")},
+		{Value: auth0.String("write:foo"), Description: auth0.String("Write foo")},
+		{Value: auth0.String("write:foo"), Description: auth0.String("Duplicate")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.ResourceServer.AddScopes("rs_123", []*ResourceServerScope{{Value: auth0.String("read:foo")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.ResourceServer.RemoveScopes("rs_123", []string{"read:foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.ResourceServer.RemoveScopes("rs_123", []string{"write:foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Expect(t, patches, []string{
+		`{"scopes":[{"value":"read:foo","description":"Read foo"},{"value":"write:foo","description":"Write foo"}]}` + "\n",
+		`{"scopes":[]}` + "\n",
+	})
+}
@@ -1,5 +1,7 @@
 package management
 
+import "errors"
+
 // Role is used to assign roles to a User.
 type Role struct {
 	// A unique ID for the role.
@@ -85,27 +87,112 @@ func (m *RoleManager) Delete(id string, opts ...RequestOption) (err error) {
 //
 // See: https://auth0.com/docs/api/management/v2#!/Roles/get_roles
 func (m *RoleManager) List(opts ...RequestOption) (r *RoleList, err error) {
-	err = m.Request("GET", m.URI("roles"), &r, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("roles"), &r, applyListDefaults(opts)...)
 	return
 }
 
+// Count returns the amount of roles matching the given options without
+// retrieving any of them.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Roles/get_roles
+func (m *RoleManager) Count(opts ...RequestOption) (int, error) {
+	return m.count(m.URI("roles"), opts)
+}
+
+// ListAll returns an iterator over all roles, transparently requesting
+// additional pages as needed. The request options are applied to every page
+// request.
+func (m *RoleManager) ListAll(opts ...RequestOption) *RoleIterator {
+	it := &RoleIterator{}
+	it.pager = NewPager(func(opts ...RequestOption) (int, error) {
+		l, err := m.List(opts...)
+		if err != nil {
+			return 0, err
+		}
+		it.roles = l.Roles
+		return len(l.Roles), nil
+	}, opts...)
+	return it
+}
+
+// RoleIterator iterates over all roles.
+type RoleIterator struct {
+	pager *Pager
+	roles []*Role
+}
+
+// Next returns the next role. Once all roles have been returned it returns
+// ErrIteratorDone, or the error of the failed request if any.
+func (it *RoleIterator) Next() (*Role, error) {
+	for len(it.roles) == 0 {
+		if !it.pager.Next() {
+			return nil, it.pager.done()
+		}
+	}
+
+	r := it.roles[0]
+	it.roles = it.roles[1:]
+
+	return r, nil
+}
+
+// roleUsersBatchSize is the maximum amount of users which can be assigned to
+// a role in a single request.
+const roleUsersBatchSize = 50
+
 // AssignUsers assigns users to a role.
 //
+// Users are assigned in batches of at most 50 users, as documented by the
+// API. If a batch fails, the error is returned and the remaining batches are
+// not sent, but the users of previous batches remain assigned.
+//
 // See: https://auth0.com/docs/api/management/v2#!/Roles/post_role_users
 func (m *RoleManager) AssignUsers(id string, users []*User, opts ...RequestOption) error {
-	u := make(map[string][]*string)
-	u["users"] = make([]*string, len(users))
-	for i, user := range users {
-		u["users"][i] = user.ID
+	if len(users) == 0 {
+		return errors.New("at least one user is required")
+	}
+	for start := 0; start < len(users); start += roleUsersBatchSize {
+		end := start + roleUsersBatchSize
+		if end > len(users) {
+			end = len(users)
+		}
+		u := make(map[string][]*string)
+		u["users"] = make([]*string, 0, end-start)
+		for _, user := range users[start:end] {
+			u["users"] = append(u["users"], user.ID)
+		}
+		if err := m.Request("POST", m.URI("roles", id, "users"), &u, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveUsers removes users from a role.
+//
+// As the API does not provide a way to remove users from a role in bulk, the
+// role is removed from each user in turn. If a removal fails, the error is
+// returned and the remaining users are left unchanged.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Users/delete_user_roles
+func (m *RoleManager) RemoveUsers(id string, users []*User, opts ...RequestOption) error {
+	if len(users) == 0 {
+		return errors.New("at least one user is required")
+	}
+	r := map[string][]*string{"roles": {&id}}
+	for _, user := range users {
+		if err := m.Request("DELETE", m.URI("users", user.GetID(), "roles"), &r, opts...); err != nil {
+			return err
+		}
 	}
-	return m.Request("POST", m.URI("roles", id, "users"), &u, opts...)
+	return nil
 }
 
 // Users retrieves all users associated with a role.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Roles/get_role_user
 func (m *RoleManager) Users(id string, opts ...RequestOption) (u *UserList, err error) {
-	err = m.Request("GET", m.URI("roles", id, "users"), &u, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("roles", id, "users"), &u, applyListDefaults(opts)...)
 	return
 }
 
@@ -122,7 +209,7 @@ func (m *RoleManager) AssociatePermissions(id string, permissions []*Permission,
 //
 // See: https://auth0.com/docs/api/management/v2#!/Roles/get_role_permission
 func (m *RoleManager) Permissions(id string, opts ...RequestOption) (p *PermissionList, err error) {
-	err = m.Request("GET", m.URI("roles", id, "permissions"), &p, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("roles", id, "permissions"), &p, applyListDefaults(opts)...)
 	return
 }
 
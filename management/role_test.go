@@ -1,10 +1,15 @@
 package management
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestRole(t *testing.T) {
@@ -138,3 +143,50 @@ func TestRole(t *testing.T) {
 		}
 	})
 }
+
+func TestRoleManagerUsersBatch(t *testing.T) {
+	var batches []int
+	var removed []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string][]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		switch r.Method {
+		case "POST":
+			expect.Expect(t, r.URL.Path, "/api/v2/roles/rol_123/users")
+			batches = append(batches, len(payload["users"]))
+		case "DELETE":
+			expect.Expect(t, payload["roles"], []string{"rol_123"})
+			removed = append(removed, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var users []*User
+	for i := 0; i < 120; i++ {
+		users = append(users, &User{ID: auth0.String(fmt.Sprintf("auth0|%d", i))})
+	}
+
+	if err := m.Role.AssignUsers("rol_123", users); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, batches, []int{50, 50, 20})
+
+	if err := m.Role.RemoveUsers("rol_123", users[:2]); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, removed, []string{"/api/v2/users/auth0|0/roles", "/api/v2/users/auth0|1/roles"})
+
+	if err := m.Role.AssignUsers("rol_123", nil); err == nil {
+		t.Error("expected an empty list of users to be rejected")
+	}
+	if err := m.Role.RemoveUsers("rol_123", nil); err == nil {
+		t.Error("expected an empty list of users to be rejected")
+	}
+}
@@ -72,6 +72,6 @@ func (m *RuleManager) Delete(id string, opts ...RequestOption) error {
 //
 // See: https://auth0.com/docs/api/management/v2#!/Rules/get_rules
 func (m *RuleManager) List(opts ...RequestOption) (r *RuleList, err error) {
-	err = m.Request("GET", m.URI("rules"), &r, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("rules"), &r, applyListDefaults(opts)...)
 	return
 }
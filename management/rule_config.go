@@ -2,10 +2,12 @@ package management
 
 // RuleConfig are key value pairs used to configure Rules.
 type RuleConfig struct {
-	// The key for a RuleConfigs config
+	// The key for a RuleConfigs config. It is read-only, the key is set using
+	// the key passed to RuleConfigManager.Upsert.
 	Key *string `json:"key,omitempty"`
 
-	// The value for the rules config
+	// The value for the rules config. It is write-only, as the API never
+	// returns it.
 	Value *string `json:"value,omitempty"`
 }
 
@@ -20,9 +22,12 @@ func newRuleConfigManager(m *Management) *RuleConfigManager {
 
 // Upsert sets a rule configuration variable.
 //
+// Only the value of r is sent, so r may be one which was previously read. The
+// key of r is populated from the response.
+//
 // See: https://auth0.com/docs/api/management/v2#!/Rules_Configs/put_rules_configs_by_key
 func (m *RuleConfigManager) Upsert(key string, r *RuleConfig, opts ...RequestOption) (err error) {
-	return m.Request("PUT", m.URI("rules-configs", key), r, opts...)
+	return m.request("PUT", m.URI("rules-configs", key), &RuleConfig{Value: r.Value}, r, opts...)
 }
 
 // Read a rule configuration variable by key.
@@ -41,7 +46,7 @@ func (m *RuleConfigManager) Read(key string, opts ...RequestOption) (*RuleConfig
 			return r, nil
 		}
 	}
-	return nil, &managementError{404, "Not Found", "Rule config not found"}
+	return nil, &ManagementError{StatusCode: 404, Err: "Not Found", Message: "Rule config not found"}
 }
 
 // Delete a rule configuration variable identified by its key.
@@ -55,6 +60,6 @@ func (m *RuleConfigManager) Delete(key string, opts ...RequestOption) (err error
 //
 // See: https://auth0.com/docs/api/management/v2#!/Rules_Configs/get_rules_configs
 func (m *RuleConfigManager) List(opts ...RequestOption) (r []*RuleConfig, err error) {
-	err = m.Request("GET", m.URI("rules-configs"), &r, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("rules-configs"), &r, applyListDefaults(opts)...)
 	return
 }
@@ -1,9 +1,13 @@
 package management
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestRuleConfig(t *testing.T) {
@@ -49,3 +53,27 @@ func TestRuleConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestRuleConfigManagerUpsert(t *testing.T) {
+	var body string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "PUT")
+		expect.Expect(t, r.URL.Path, "/api/v2/rules-configs/foo")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Write([]byte(`{"key":"foo","value":"baz"}`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &RuleConfig{Key: auth0.String("foo"), Value: auth0.String("baz")}
+	if err := m.RuleConfig.Upsert("foo", r); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, body, `{"value":"baz"}`+"\n")
+	expect.Expect(t, r.GetKey(), "foo")
+}
@@ -66,7 +66,35 @@ func (m *SigningKeyManager) Read(kid string, opts ...RequestOption) (k *SigningK
 	return
 }
 
-// Rotate the Application Signing Key.
+// Current retrieves the Application Signing Key currently used to sign
+// tokens, e.g. to confirm that a rotation took effect.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Keys/get_signing_keys
+func (m *SigningKeyManager) Current(opts ...RequestOption) (*SigningKey, error) {
+	ks, err := m.List(opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range ks {
+		if k.GetCurrent() {
+			return k, nil
+		}
+	}
+	return nil, &ManagementError{
+		StatusCode: 404,
+		Err:        "Not Found",
+		Message:    "Current signing key not found",
+	}
+}
+
+// Rotate the Application Signing Key. The next key becomes the current key
+// and the returned key only holds its KID and Cert, use Read to retrieve its
+// other properties.
+//
+// The previous key remains published in the tenant JWKS so that tokens it
+// signed can still be validated. Note that applications caching the JWKS may
+// take some time to pick up the new key, so it should be published to them,
+// e.g. by waiting for the cache to expire, before relying on tokens it signs.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Keys/post_signing_keys
 func (m *SigningKeyManager) Rotate(opts ...RequestOption) (k *SigningKey, err error) {
@@ -74,7 +102,12 @@ func (m *SigningKeyManager) Rotate(opts ...RequestOption) (k *SigningKey, err er
 	return
 }
 
-// Revoke an Application Signing Key by its key id.
+// Revoke an Application Signing Key by its key id. The key is removed from
+// the tenant JWKS, so tokens it signed can no longer be validated once
+// applications refresh their cache of the JWKS.
+//
+// Only the previous key can be revoked: revoking the current or the next key
+// is rejected by the Auth0 Management API, so Rotate must be called first.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Keys/put_signing_keys
 func (m *SigningKeyManager) Revoke(kid string, opts ...RequestOption) (k *SigningKey, err error) {
@@ -1,7 +1,11 @@
 package management
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestSigningKey(t *testing.T) {
@@ -69,3 +73,28 @@ func TestSigningKey(t *testing.T) {
 		t.Logf("%v\n", r)
 	})
 }
+
+func TestSigningKeyManagerCurrent(t *testing.T) {
+	keys := `[{"kid":"key_1","previous":true},{"kid":"key_2","current":true},{"kid":"key_3","next":true}]`
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(keys))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := m.SigningKey.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, k.GetKID(), "key_2")
+
+	keys = `[{"kid":"key_1","revoked":true}]`
+	_, err = m.SigningKey.Current()
+	if mErr, ok := err.(*ManagementError); !ok || mErr.Status() != http.StatusNotFound {
+		t.Errorf("expected a 404 error, got %v", err)
+	}
+}
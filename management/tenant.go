@@ -30,6 +30,9 @@ type Tenant struct {
 
 	Flags *TenantFlags `json:"flags,omitempty"`
 
+	// Session cookie settings
+	SessionCookie *TenantSessionCookie `json:"session_cookie,omitempty"`
+
 	// The friendly name of the tenant
 	FriendlyName *string `json:"friendly_name,omitempty"`
 
@@ -198,6 +201,65 @@ type TenantFlags struct {
 	// If enabled, this will use the scope description when generating a consent
 	// prompt. Otherwise, the scope name is used.
 	UseScopeDescriptionsForConsent *bool `json:"use_scope_descriptions_for_consent,omitempty"`
+
+	// If enabled, the legacy delegation grant types are allowed.
+	AllowLegacyDelegationGrantTypes *bool `json:"allow_legacy_delegation_grant_types,omitempty"`
+
+	// If enabled, the legacy resource owner grant types are allowed.
+	AllowLegacyROGrantTypes *bool `json:"allow_legacy_ro_grant_types,omitempty"`
+
+	// If enabled, the legacy /tokeninfo endpoint can be used.
+	AllowLegacyTokenInfoEndpoint *bool `json:"allow_legacy_tokeninfo_endpoint,omitempty"`
+
+	// If enabled, legacy user profiles are returned by the authentication API.
+	EnableLegacyProfile *bool `json:"enable_legacy_profile,omitempty"`
+
+	// If enabled, ID tokens can be used to authorize some /api/v2 endpoints.
+	EnableIDTokenAPI2 *bool `json:"enable_idtoken_api2,omitempty"`
+
+	// If enabled, users are presented with the list of available factors when
+	// enrolling in MFA.
+	MFAShowFactorListOnEnrollment *bool `json:"mfa_show_factor_list_on_enrollment,omitempty"`
+
+	// If enabled, enterprise connections are not disclosed on the login page
+	// when using Home Realm Discovery.
+	NoDiscloseEnterpriseConnections *bool `json:"no_disclose_enterprise_connections,omitempty"`
+
+	// If enabled, refresh tokens are revoked when the refresh token grant is
+	// removed from a client.
+	RevokeRefreshTokenGrant *bool `json:"revoke_refresh_token_grant,omitempty"`
+
+	// Extra holds any flags not explicitly modeled by the SDK. They are
+	// preserved when the flags are read and sent back on update, so that
+	// updating the flags does not reset the ones which were not touched.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON is a custom serializer for the TenantFlags type.
+func (f *TenantFlags) MarshalJSON() ([]byte, error) {
+	type flags TenantFlags
+	return marshalWithExtra((*flags)(f), f.Extra)
+}
+
+// UnmarshalJSON is a custom deserializer for the TenantFlags type.
+func (f *TenantFlags) UnmarshalJSON(b []byte) (err error) {
+	type flags TenantFlags
+	f.Extra, err = unmarshalWithExtra(b, (*flags)(f))
+	return err
+}
+
+const (
+	// TenantSessionCookieModePersistent constant.
+	TenantSessionCookieModePersistent = "persistent"
+	// TenantSessionCookieModeNonPersistent constant.
+	TenantSessionCookieModeNonPersistent = "non-persistent"
+)
+
+// TenantSessionCookie holds settings for the session cookie.
+type TenantSessionCookie struct {
+	// Behavior of the session cookie, either "persistent" or
+	// "non-persistent". See the TenantSessionCookieMode constants.
+	Mode *string `json:"mode,omitempty"`
 }
 
 // TenantUniversalLogin holds universal login settings.
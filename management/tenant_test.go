@@ -59,3 +59,22 @@ func TestTenant(t *testing.T) {
 		}
 	})
 }
+
+func TestTenantFlagsExtra(t *testing.T) {
+	b := []byte(`{"flags":{"enable_sso":true,"dashboard_insights_view":true},"session_cookie":{"mode":"non-persistent"}}`)
+
+	var tn *Tenant
+	if err := json.Unmarshal(b, &tn); err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, tn.GetFlags().GetEnableSSO(), true)
+	expect.Expect(t, tn.GetFlags().Extra, map[string]interface{}{"dashboard_insights_view": true})
+	expect.Expect(t, tn.GetSessionCookie().GetMode(), TenantSessionCookieModeNonPersistent)
+
+	tn.Flags.EnableSSO = auth0.Bool(false)
+	actual, err := json.Marshal(tn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, string(actual), `{"flags":{"dashboard_insights_view":true,"enable_sso":false},"session_cookie":{"mode":"non-persistent"}}`)
+}
@@ -45,6 +45,22 @@ type Ticket struct {
 	// Whether to include the email address as part of the returnUrl in
 	// the reset_email (true), or not (false - default).
 	IncludeEmailInRedirect *bool `json:"includeEmailInRedirect,omitempty"`
+
+	// The identity of the user for which the email verification ticket is to
+	// be created. It is required to verify the email of a secondary identity
+	// linked to the user.
+	//
+	// Requires: UserID
+	Identity *TicketIdentity `json:"identity,omitempty"`
+}
+
+// TicketIdentity identifies one of the identities of a user.
+type TicketIdentity struct {
+	// The user_id of the identity.
+	UserID *string `json:"user_id,omitempty"`
+
+	// The provider of the identity, e.g. "google-oauth2".
+	Provider *string `json:"provider,omitempty"`
 }
 
 // TicketManager manages Auth0 Ticket resources.
@@ -1,9 +1,11 @@
 package management
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/auth0/go-auth0"
+	"github.com/auth0/go-auth0/internal/testing/expect"
 )
 
 func TestTicket(t *testing.T) {
@@ -55,3 +57,26 @@ func TestTicket(t *testing.T) {
 		t.Logf("%v\n", v)
 	})
 }
+
+func TestTicketIdentityJSON(t *testing.T) {
+	for _, test := range []struct {
+		ticket *Ticket
+		json   string
+	}{
+		{&Ticket{UserID: auth0.String("auth0|123")}, `{"user_id":"auth0|123"}`},
+		{&Ticket{
+			UserID:   auth0.String("auth0|123"),
+			ClientID: auth0.String("client_123"),
+			Identity: &TicketIdentity{
+				UserID:   auth0.String("456"),
+				Provider: auth0.String("google-oauth2"),
+			},
+		}, `{"user_id":"auth0|123","client_id":"client_123","identity":{"user_id":"456","provider":"google-oauth2"}}`},
+	} {
+		b, err := json.Marshal(test.ticket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect.Expect(t, string(b), test.json)
+	}
+}
@@ -2,6 +2,7 @@ package management
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -354,14 +355,95 @@ func (m *UserManager) Delete(id string, opts ...RequestOption) (err error) {
 	return m.Request("DELETE", m.URI("users", id), nil, opts...)
 }
 
+// DeleteMany deletes several users based on their ids, one request each,
+// returning every failure; use WithConcurrency to delete them in parallel.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Users/delete_users_by_id
+func (m *UserManager) DeleteMany(ids []string, opts ...RequestOption) error {
+	return forEachConcurrently(len(ids), opts, func(i int) error {
+		if err := m.Delete(ids[i], opts...); err != nil {
+			return fmt.Errorf("deleting user %q failed: %w", ids[i], err)
+		}
+		return nil
+	})
+}
+
 // List all users. This method forces the `include_totals` option.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Users/get_users
 func (m *UserManager) List(opts ...RequestOption) (ul *UserList, err error) {
-	err = m.Request("GET", m.URI("users"), &ul, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("users"), &ul, applyListDefaults(opts)...)
 	return
 }
 
+// Count returns the amount of users matching the given options, e.g. Query,
+// without retrieving any of them.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Users/get_users
+func (m *UserManager) Count(opts ...RequestOption) (int, error) {
+	return m.count(m.URI("users"), opts)
+}
+
+// ListAll returns an iterator over all users, transparently requesting
+// additional pages as needed. The request options are applied to every page
+// request.
+func (m *UserManager) ListAll(opts ...RequestOption) *UserIterator {
+	it := &UserIterator{}
+	it.pager = NewPager(func(opts ...RequestOption) (int, error) {
+		l, err := m.List(opts...)
+		if err != nil {
+			return 0, err
+		}
+		it.users = l.Users
+		return len(l.Users), nil
+	}, opts...)
+	return it
+}
+
+// UserIterator iterates over all users.
+type UserIterator struct {
+	pager *Pager
+	users []*User
+}
+
+// Next returns the next user. Once all users have been returned it returns
+// ErrIteratorDone, or the error of the failed request if any.
+func (it *UserIterator) Next() (*User, error) {
+	for len(it.users) == 0 {
+		if !it.pager.Next() {
+			return nil, it.pager.done()
+		}
+	}
+
+	u := it.users[0]
+	it.users = it.users[1:]
+
+	return u, nil
+}
+
+// ListStream retrieves all users, calling fn for each user as it is decoded
+// from the response payload instead of holding entire pages in memory.
+// Additional pages are requested as needed.
+//
+// Returning an error from fn stops the retrieval, and the error is returned
+// by ListStream.
+func (m *UserManager) ListStream(fn func(u *User) error, opts ...RequestOption) error {
+	p := NewPager(func(opts ...RequestOption) (int, error) {
+		return m.stream(m.URI("users"), "users", func(dec *json.Decoder) error {
+			var u *User
+			if err := dec.Decode(&u); err != nil {
+				return fmt.Errorf("decoding response payload failed: %w", err)
+			}
+			return fn(u)
+		}, applyListDefaults(opts)...)
+	}, opts...)
+	for {
+		if !p.Next() {
+			return p.Err()
+		}
+	}
+}
+
 // Search is an alias for List.
 func (m *UserManager) Search(opts ...RequestOption) (ul *UserList, err error) {
 	return m.List(opts...)
@@ -391,7 +473,7 @@ func (m *UserManager) ListByEmail(email string, opts ...RequestOption) (us []*Us
 //
 // See: https://auth0.com/docs/api/management/v2#!/Users/get_user_roles
 func (m *UserManager) Roles(id string, opts ...RequestOption) (r *RoleList, err error) {
-	err = m.Request("GET", m.URI("users", id, "roles"), &r, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("users", id, "roles"), &r, applyListDefaults(opts)...)
 	return
 }
 
@@ -423,7 +505,7 @@ func (m *UserManager) RemoveRoles(id string, roles []*Role, opts ...RequestOptio
 //
 // See: https://auth0.com/docs/api/management/v2#!/Users/get_permissions
 func (m *UserManager) Permissions(id string, opts ...RequestOption) (p *PermissionList, err error) {
-	err = m.Request("GET", m.URI("users", id, "permissions"), &p, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("users", id, "permissions"), &p, applyListDefaults(opts)...)
 	return
 }
 
@@ -534,7 +616,7 @@ func (m *UserManager) Link(id string, il *UserIdentityLink, opts ...RequestOptio
 	}
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return uIDs, newError(res.Body)
+		return uIDs, newError(res)
 	}
 
 	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusAccepted {
@@ -548,10 +630,20 @@ func (m *UserManager) Link(id string, il *UserIdentityLink, opts ...RequestOptio
 	return uIDs, nil
 }
 
+// Unlink unlinks the identity of the secondary user account identified by
+// provider and userID from the user, returning the remaining identities of the
+// user.
+//
+// See: https://auth0.com/docs/api/management/v2#!/Users/delete_user_identity_by_user_id
+func (m *UserManager) Unlink(id, provider, userID string, opts ...RequestOption) (uIDs []UserIdentity, err error) {
+	err = m.request("DELETE", m.URI("users", id, "identities", provider, userID), nil, &uIDs, opts...)
+	return
+}
+
 // Organizations lists user's organizations.
 //
 // See: https://auth0.com/docs/api/management/v2#!/Users/get_organizations
 func (m *UserManager) Organizations(id string, opts ...RequestOption) (p *OrganizationList, err error) {
-	err = m.Request("GET", m.URI("users", id, "organizations"), &p, applyListDefaults(opts))
+	err = m.Request("GET", m.URI("users", id, "organizations"), &p, applyListDefaults(opts)...)
 	return
 }
@@ -1,7 +1,12 @@
 package management
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -213,6 +218,14 @@ func TestUser(t *testing.T) {
 		}
 	})
 
+	t.Run("Organizations", func(t *testing.T) {
+		organizations, err := m.User.Organizations(auth0.StringValue(u.ID))
+		if err != nil {
+			t.Error(err)
+		}
+		t.Logf("%v\n", organizations)
+	})
+
 	t.Run("Blocks", func(t *testing.T) {
 		b, err := m.User.Blocks(u.GetID())
 		if err != nil {
@@ -402,3 +415,120 @@ func TestUserIdentity(t *testing.T) {
 		}
 	})
 }
+
+func TestUserManagerListStream(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "0":
+			w.Write([]byte(`{"start":0,"limit":2,"length":2,"users":[{"user_id":"1"},{"user_id":"2"}],"total":3}`))
+		case "1":
+			w.Write([]byte(`{"start":2,"limit":2,"length":1,"users":[{"user_id":"3"}],"total":3}`))
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	err = m.User.ListStream(func(u *User) error {
+		ids = append(ids, u.GetID())
+		return nil
+	}, PerPage(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, ids, []string{"1", "2", "3"})
+
+	stop := errors.New("stop")
+	ids = nil
+	err = m.User.ListStream(func(u *User) error {
+		ids = append(ids, u.GetID())
+		return stop
+	}, PerPage(2))
+	expect.Expect(t, err, stop)
+	expect.Expect(t, ids, []string{"1"})
+}
+
+func TestUserManagerUnlink(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "DELETE")
+		expect.Expect(t, r.URL.Path, "/api/v2/users/auth0|123/identities/google-oauth2/456")
+		w.Write([]byte(`[{"connection":"Username-Password-Authentication","provider":"auth0","user_id":"123"}]`))
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identities, err := m.User.Unlink("auth0|123", "google-oauth2", "456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, len(identities), 1)
+	expect.Expect(t, identities[0].GetProvider(), "auth0")
+	expect.Expect(t, identities[0].GetUserID(), "123")
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer slow.Close()
+
+	m, err = New(slow.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.User.Unlink("auth0|123", "google-oauth2", "456", WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected err to be context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestUserManagerDeleteMany(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect.Expect(t, r.Method, "DELETE")
+		mu.Lock()
+		attempts[r.URL.Path]++
+		n := attempts[r.URL.Path]
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/v2/users/auth0|2" && n == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/api/v2/users/auth0|3":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"The user does not exist."}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer s.Close()
+
+	m, err := New(s.URL, WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.User.DeleteMany([]string{"auth0|1", "auth0|2", "auth0|3", "auth0|4"}, WithConcurrency(2))
+	expect.Expect(t, err.Error(), `deleting user "auth0|3" failed: 404 Not Found: The user does not exist.`)
+	expect.Expect(t, attempts, map[string]int{
+		"/api/v2/users/auth0|1": 1,
+		"/api/v2/users/auth0|2": 2,
+		"/api/v2/users/auth0|3": 1,
+		"/api/v2/users/auth0|4": 1,
+	})
+}